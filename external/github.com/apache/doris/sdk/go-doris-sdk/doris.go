@@ -0,0 +1,155 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package doris provides a high-level API for loading data into Apache Doris
+// This is a backward-compatible wrapper that re-exports functionality from pkg/load
+package doris
+
+import "github.com/apache/doris/sdk/go-doris-sdk/pkg/load"
+
+// Config aliases
+type Config = load.Config
+
+// Client aliases
+type DorisLoadClient = load.DorisLoadClient
+type ReaderJoin = load.ReaderJoin
+
+// Format aliases
+type Format = load.Format
+type JSONFormatType = load.JSONFormatType
+type JSONFormat = load.JSONFormat
+type CSVFormat = load.CSVFormat
+
+// Log aliases
+type LogLevel = load.LogLevel
+type LogFormat = load.LogFormat
+type LogFunc = load.LogFunc
+type Logger = load.Logger
+type ContextLogger = load.ContextLogger
+
+// Load response aliases
+type LoadResponse = load.LoadResponse
+type LoadStatus = load.LoadStatus
+type ColumnInfo = load.ColumnInfo
+type LoadTrace = load.LoadTrace
+type ConnectionPool = load.ConnectionPool
+type CircuitBreakerConfig = load.CircuitBreakerConfig
+type CircuitState = load.CircuitState
+type LoadStats = load.LoadStats
+type LoadStatsSnapshot = load.LoadStatsSnapshot
+
+// Error aliases
+type StreamLoadError = load.StreamLoadError
+type AuthError = load.AuthError
+type ConnectionError = load.ConnectionError
+type DataQualityError = load.DataQualityError
+type LabelExistsError = load.LabelExistsError
+type OutcomeUnknownError = load.OutcomeUnknownError
+type AllEndpointsFailedError = load.AllEndpointsFailedError
+type EndpointError = load.EndpointError
+
+// Enum constants
+const (
+	// JSON format constants
+	JSONObjectLine = load.JSONObjectLine
+	JSONArray      = load.JSONArray
+
+	// Group commit constants
+	SYNC  = load.SYNC
+	ASYNC = load.ASYNC
+	OFF   = load.OFF
+
+	// Load status constants
+	SUCCESS = load.SUCCESS
+	FAILURE = load.FAILURE
+
+	// Log level constants
+	LogLevelDebug = load.LogLevelDebug
+	LogLevelInfo  = load.LogLevelInfo
+	LogLevelWarn  = load.LogLevelWarn
+	LogLevelError = load.LogLevelError
+
+	// Log format constants
+	LogFormatText = load.LogFormatText
+	LogFormatJSON = load.LogFormatJSON
+
+	// Circuit breaker state constants
+	CircuitClosed   = load.CircuitClosed
+	CircuitOpen     = load.CircuitOpen
+	CircuitHalfOpen = load.CircuitHalfOpen
+
+	// Default size up to which load data is held in memory
+	DefaultMaxBufferBytes = load.DefaultMaxBufferBytes
+
+	// Default largest response body read from Doris
+	DefaultMaxResponseBytes = load.DefaultMaxResponseBytes
+
+	// Longest label Doris accepts
+	DefaultMaxLabelLength = load.DefaultMaxLabelLength
+
+	// Hidden column of unique key tables marking the rows to delete
+	DeleteSignColumn = load.DeleteSignColumn
+
+	// SDK version and the User-Agent header sent by default
+	Version          = load.Version
+	DefaultUserAgent = load.DefaultUserAgent
+)
+
+// GroupCommitMode aliases
+type GroupCommitMode = load.GroupCommitMode
+type Retry = load.Retry
+type RetryBuilder = load.RetryBuilder
+type CSVFormatBuilder = load.CSVFormatBuilder
+type ValidationError = load.ValidationError
+
+// Function aliases for easy access
+var (
+	// Client functions
+	NewLoadClient             = load.NewLoadClient
+	WithExpectedRows          = load.WithExpectedRows
+	SetGlobalConcurrencyLimit = load.SetGlobalConcurrencyLimit
+
+	// Errors
+	ErrResponseTooLarge = load.ErrResponseTooLarge
+
+	// Data conversion helpers
+	StringReader    = load.StringReader
+	BytesReader     = load.BytesReader
+	JSONReader      = load.JSONReader
+	LineReader      = load.LineReader
+	JSONArrayReader = load.JSONArrayReader
+	JSONColumns     = load.JSONColumns
+
+	// Logging functions
+	SetLogLevel       = load.SetLogLevel
+	SetLogFormat      = load.SetLogFormat
+	SetLogOutput      = load.SetLogOutput
+	DisableLogging    = load.DisableLogging
+	SetCustomLogFunc  = load.SetCustomLogFunc
+	SetCustomLogFuncs = load.SetCustomLogFuncs
+	SetLogger         = load.SetLogger
+	NewContextLogger  = load.NewContextLogger
+
+	// Default configuration builders
+	DefaultJSONFormat = load.DefaultJSONFormat
+	DefaultCSVFormat  = load.DefaultCSVFormat
+	NewCSVFormat      = load.NewCSVFormat
+	DefaultRetry      = load.DefaultRetry
+	NewRetry          = load.NewRetry
+	NewDefaultRetry   = load.NewDefaultRetry
+	NewRetryBuilder   = load.NewRetryBuilder
+)
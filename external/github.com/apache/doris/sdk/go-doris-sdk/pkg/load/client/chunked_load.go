@@ -0,0 +1,237 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+	log "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/logger"
+)
+
+// LoadLargeFile splits the data at record boundaries into chunks of at most chunkBytes and loads them one
+// after the other, each as its own load job, so that a single load does not exceed the limits of FE
+// Only the current chunk is held in memory. Loading stops at the first failed chunk, in which case the
+// previous chunks are already loaded and the returned response counts their rows
+func (c *DorisLoadClient) LoadLargeFile(reader io.Reader, chunkBytes int64) (*loader.LoadResponse, error) {
+	if chunkBytes <= 0 {
+		return nil, fmt.Errorf("chunkBytes must be positive, got %d", chunkBytes)
+	}
+
+	cfg := c.currentConfig()
+	chunks := newChunker(reader, cfg.Format, chunkBytes)
+
+	aggregate := &loader.LoadResponse{Status: loader.SUCCESS}
+	aggregate.Resp.Status = loader.StatusSuccess
+	start := time.Now()
+	defer func() { aggregate.Duration = time.Since(start) }()
+	var labels, attemptLabels []string
+	index := 0
+	for ; ; index++ {
+		chunk, err := chunks.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return chunkFailure(aggregate, labels, attemptLabels, index, fmt.Errorf("failed to read chunk: %w", err))
+		}
+
+		log.Infof("Loading chunk %d of %d bytes", index, len(chunk))
+		response, err := c.chunkClient(cfg, index).Load(bytes.NewReader(chunk))
+		if response != nil {
+			aggregate.Attempts += response.Attempts
+			if response.Label != "" {
+				attemptLabels = append(attemptLabels, response.Label)
+			}
+		}
+		if err == nil && (response == nil || response.Status != loader.SUCCESS) {
+			err = fmt.Errorf("load failed")
+		}
+		if err != nil {
+			return chunkFailure(aggregate, labels, attemptLabels, index, err)
+		}
+
+		resp := response.Resp
+		addRowCounts(&aggregate.Resp, &resp)
+		// Chunks are loaded one after the other, so their load times add up
+		aggregate.Resp.LoadTimeMs += resp.LoadTimeMs
+		if resp.Label != "" {
+			labels = append(labels, resp.Label)
+		}
+	}
+
+	if index == 0 {
+		return c.Load(bytes.NewReader(nil))
+	}
+	aggregate.Resp.Label = strings.Join(labels, ",")
+	aggregate.Label = strings.Join(attemptLabels, ",")
+	log.Infof("Chunked load completed, %d chunks, %d rows loaded", index, aggregate.Resp.NumberLoadedRows)
+	return aggregate, nil
+}
+
+// chunkClient creates a client loading the chunk with the given index
+func (c *DorisLoadClient) chunkClient(base *config.Config, index int) *DorisLoadClient {
+	cfg := *base
+	if cfg.Label != "" {
+		// Each chunk is a separate load job and needs its own label
+		cfg.Label = fmt.Sprintf("%s_chunk_%d", cfg.Label, index)
+	}
+	return c.withConfig(&cfg)
+}
+
+// chunkFailure marks the aggregate of the chunks loaded so far as failed at the given chunk
+func chunkFailure(aggregate *loader.LoadResponse, labels, attemptLabels []string, index int, err error) (*loader.LoadResponse, error) {
+	aggregate.Status = loader.FAILURE
+	aggregate.Resp.Status = loader.StatusFail
+	aggregate.Resp.Label = strings.Join(labels, ",")
+	aggregate.Label = strings.Join(attemptLabels, ",")
+	aggregate.ErrorMessage = fmt.Sprintf("chunk %d: %v", index, err)
+	log.Errorf("Chunked load failed after %d chunks were loaded: %s", index, aggregate.ErrorMessage)
+	return aggregate, fmt.Errorf("chunk %d failed after %d chunks were loaded: %w", index, index, err)
+}
+
+// chunker groups the records read from a reader into chunks of limited size
+type chunker struct {
+	record  func() ([]byte, error) // Returns the next record, io.EOF after the last one
+	open    []byte                 // Written before the first record of a chunk
+	sep     []byte                 // Written between records
+	close   []byte                 // Written after the last record of a chunk
+	limit   int64
+	pending []byte // Record read but not fitting the previous chunk
+}
+
+// newChunker creates a chunker splitting JSON arrays into arrays and other formats at their line delimiter
+func newChunker(reader io.Reader, format config.Format, limit int64) *chunker {
+	if jsonFormat, ok := format.(*config.JSONFormat); ok && jsonFormat.Type == config.JSONArray {
+		return &chunker{
+			record: jsonArrayRecords(reader),
+			open:   []byte{'['},
+			sep:    []byte{','},
+			close:  []byte{']'},
+			limit:  limit,
+		}
+	}
+
+	delimiter := []byte("\n")
+	if csvFormat, ok := format.(*config.CSVFormat); ok {
+		delimiter = []byte(csvFormat.LineDelimiter)
+	}
+	return &chunker{
+		record: lineRecords(bufio.NewReader(reader), delimiter),
+		limit:  limit,
+	}
+}
+
+// next returns the next chunk, io.EOF when all records were returned
+// A record larger than the limit on its own fails since it cannot be split
+func (c *chunker) next() ([]byte, error) {
+	var chunk bytes.Buffer
+	count := 0
+	for {
+		record := c.pending
+		c.pending = nil
+		if record == nil {
+			var err error
+			record, err = c.record()
+			if errors.Is(err, io.EOF) && count > 0 {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		size := int64(chunk.Len() + len(record) + len(c.close))
+		if count == 0 {
+			size += int64(len(c.open))
+		} else {
+			size += int64(len(c.sep))
+		}
+		if size > c.limit {
+			if count == 0 {
+				return nil, fmt.Errorf("record of %d bytes does not fit in a chunk of %d bytes", len(record), c.limit)
+			}
+			c.pending = record
+			break
+		}
+
+		if count == 0 {
+			chunk.Write(c.open)
+		} else {
+			chunk.Write(c.sep)
+		}
+		chunk.Write(record)
+		count++
+	}
+	chunk.Write(c.close)
+	return chunk.Bytes(), nil
+}
+
+// lineRecords returns the records of the reader ending with the delimiter, which is added to a last record missing it
+func lineRecords(reader *bufio.Reader, delimiter []byte) func() ([]byte, error) {
+	last := delimiter[len(delimiter)-1]
+	return func() ([]byte, error) {
+		var record []byte
+		for {
+			line, err := reader.ReadBytes(last)
+			record = append(record, line...)
+			if err == nil && bytes.HasSuffix(record, delimiter) {
+				return record, nil
+			}
+			if errors.Is(err, io.EOF) {
+				if len(record) == 0 {
+					return nil, io.EOF
+				}
+				return append(record, delimiter...), nil
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// jsonArrayRecords returns the elements of the JSON array read from the reader
+func jsonArrayRecords(reader io.Reader) func() ([]byte, error) {
+	decoder := json.NewDecoder(reader)
+	started := false
+	return func() ([]byte, error) {
+		if !started {
+			if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+				return nil, fmt.Errorf("data is not a JSON array")
+			}
+			started = true
+		}
+		if !decoder.More() {
+			return nil, io.EOF
+		}
+		var record json.RawMessage
+		if err := decoder.Decode(&record); err != nil {
+			return nil, fmt.Errorf("invalid JSON array element: %w", err)
+		}
+		return record, nil
+	}
+}
@@ -0,0 +1,175 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+)
+
+func TestChunker(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		format   config.Format
+		limit    int64
+		expected []string
+	}{
+		{
+			name:     "json lines",
+			data:     "{\"a\":1}\n{\"a\":2}\n{\"a\":3}\n",
+			format:   &config.JSONFormat{Type: config.JSONObjectLine},
+			limit:    16,
+			expected: []string{"{\"a\":1}\n{\"a\":2}\n", "{\"a\":3}\n"},
+		},
+		{
+			name:     "json lines without trailing newline",
+			data:     "{\"a\":1}\n{\"a\":2}\n{\"a\":3}",
+			format:   &config.JSONFormat{Type: config.JSONObjectLine},
+			limit:    20,
+			expected: []string{"{\"a\":1}\n{\"a\":2}\n", "{\"a\":3}\n"},
+		},
+		{
+			name:     "csv",
+			data:     "1,a\n2,b\n3,c\n4,d\n5,e\n",
+			format:   &config.CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"},
+			limit:    9,
+			expected: []string{"1,a\n2,b\n", "3,c\n4,d\n", "5,e\n"},
+		},
+		{
+			name:     "csv with multi-byte delimiter",
+			data:     "1,a\r\n2,b\r\n3,c",
+			format:   &config.CSVFormat{ColumnSeparator: ",", LineDelimiter: "\r\n"},
+			limit:    10,
+			expected: []string{"1,a\r\n2,b\r\n", "3,c\r\n"},
+		},
+		{
+			name:     "json array",
+			data:     `[{"a":1}, {"a":[2,3]}, {"a":"x,y"}]`,
+			format:   &config.JSONFormat{Type: config.JSONArray},
+			limit:    24,
+			expected: []string{`[{"a":1},{"a":[2,3]}]`, `[{"a":"x,y"}]`},
+		},
+		{
+			name:     "everything fits",
+			data:     "1,a\n2,b\n",
+			format:   &config.CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"},
+			limit:    1024,
+			expected: []string{"1,a\n2,b\n"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chunks := newChunker(strings.NewReader(tc.data), tc.format, tc.limit)
+			var got []string
+			for {
+				chunk, err := chunks.next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if int64(len(chunk)) > tc.limit {
+					t.Errorf("chunk of %d bytes exceeds the limit %d", len(chunk), tc.limit)
+				}
+				got = append(got, string(chunk))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected chunks %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestChunkerRecordTooLarge(t *testing.T) {
+	chunks := newChunker(strings.NewReader("1,a\n22222,b\n"), &config.CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"}, 6)
+	if _, err := chunks.next(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := chunks.next(); err == nil || !strings.Contains(err.Error(), "does not fit") {
+		t.Fatalf("expected record too large error, got %v", err)
+	}
+}
+
+func TestLoadLargeFile(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	failAt := -1
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		failed := len(bodies)-1 == failAt
+		mu.Unlock()
+
+		rows := strings.Count(string(body), "\n")
+		status := "Success"
+		if failed {
+			status = "Fail"
+		}
+		fmt.Fprintf(w, `{"Status":%q,"Label":%q,"NumberTotalRows":%d,"NumberLoadedRows":%d,"LoadBytes":%d,"LoadTimeMs":2}`,
+			status, r.Header.Get("label"), rows, rows, len(body))
+	})
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var data strings.Builder
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(&data, "{\"id\":%d}\n", i)
+	}
+
+	// Each record is 9 bytes, so 3 records fit in a chunk of 30 bytes
+	response, err := client.LoadLargeFile(strings.NewReader(data.String()), 30)
+	if err != nil {
+		t.Fatalf("chunked load failed: %v", err)
+	}
+	if len(bodies) != 4 || strings.Join(bodies, "") != data.String() {
+		t.Fatalf("expected 4 chunks covering the data, got %q", bodies)
+	}
+	if response.Status != loader.SUCCESS || response.Resp.NumberLoadedRows != 10 || response.Resp.LoadTimeMs != 8 || response.Attempts != 4 {
+		t.Errorf("unexpected aggregate response: %+v", response)
+	}
+	if labels := strings.Split(response.Resp.Label, ","); len(labels) != 4 {
+		t.Errorf("expected 4 chunk labels, got %q", response.Resp.Label)
+	}
+
+	// Loading stops at the first failed chunk
+	bodies = nil
+	failAt = 1
+	response, err = client.LoadLargeFile(strings.NewReader(data.String()), 30)
+	if err == nil {
+		t.Fatal("expected an error when a chunk fails")
+	}
+	if len(bodies) != 2 {
+		t.Errorf("expected loading to stop after 2 chunks, got %d", len(bodies))
+	}
+	if response.Status != loader.FAILURE || response.Resp.NumberLoadedRows != 3 || !strings.Contains(response.ErrorMessage, "chunk 1") {
+		t.Errorf("unexpected aggregate response: %+v", response)
+	}
+}
@@ -0,0 +1,163 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/exception"
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+)
+
+// errCircuitOpen is returned when the circuit breakers of all endpoints are open
+var errCircuitOpen = errors.New("all endpoints are unavailable: circuit breakers are open")
+
+// endpointCircuit is the breaker state of a single endpoint
+type endpointCircuit struct {
+	state    config.CircuitState
+	failures int
+	openedAt time.Time
+}
+
+// stateChange is a state change to report after releasing the lock
+type stateChange struct {
+	endpoint string
+	from, to config.CircuitState
+}
+
+// circuitBreaker tracks the health of the endpoints shared by all loads of a client
+type circuitBreaker struct {
+	mu       sync.Mutex
+	circuits map[string]*endpointCircuit
+	now      func() time.Time
+}
+
+// newCircuitBreaker creates a circuit breaker with all endpoints closed
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{
+		circuits: make(map[string]*endpointCircuit),
+		now:      time.Now,
+	}
+}
+
+// pick chooses a random endpoint that accepts loads according to the endpoint weights, moving an open endpoint past its reset timeout to half-open
+// A half-open endpoint accepts only the single probe it was picked for until the probe is recorded
+func (b *circuitBreaker) pick(loadCfg *config.Config) (string, bool) {
+	cfg := loadCfg.CircuitBreaker
+	b.mu.Lock()
+	var candidates []string
+	for _, endpoint := range loadCfg.Endpoints {
+		circuit := b.circuit(endpoint)
+		switch circuit.state {
+		case config.CircuitClosed:
+			candidates = append(candidates, endpoint)
+		case config.CircuitOpen:
+			if b.now().Sub(circuit.openedAt) >= cfg.ResetTimeout {
+				candidates = append(candidates, endpoint)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		b.mu.Unlock()
+		return "", false
+	}
+
+	endpoint := loadCfg.PickEndpoint(candidates)
+	var changes []stateChange
+	if circuit := b.circuits[endpoint]; circuit.state == config.CircuitOpen {
+		changes = append(changes, b.transition(endpoint, circuit, config.CircuitHalfOpen))
+	}
+	b.mu.Unlock()
+
+	b.notify(cfg, changes)
+	return endpoint, true
+}
+
+// record updates the breaker of the endpoint with the result of a load attempt
+func (b *circuitBreaker) record(cfg *config.CircuitBreakerConfig, endpoint string, failed bool) {
+	b.mu.Lock()
+	circuit := b.circuit(endpoint)
+	var changes []stateChange
+	switch {
+	case !failed:
+		circuit.failures = 0
+		if circuit.state != config.CircuitClosed {
+			changes = append(changes, b.transition(endpoint, circuit, config.CircuitClosed))
+		}
+	case circuit.state == config.CircuitHalfOpen:
+		circuit.openedAt = b.now()
+		changes = append(changes, b.transition(endpoint, circuit, config.CircuitOpen))
+	case circuit.state == config.CircuitClosed:
+		circuit.failures++
+		if circuit.failures >= cfg.FailureThreshold {
+			circuit.openedAt = b.now()
+			changes = append(changes, b.transition(endpoint, circuit, config.CircuitOpen))
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(cfg, changes)
+}
+
+// circuit returns the breaker of the endpoint, creating a closed one if needed, the lock must be held
+func (b *circuitBreaker) circuit(endpoint string) *endpointCircuit {
+	circuit, ok := b.circuits[endpoint]
+	if !ok {
+		circuit = &endpointCircuit{state: config.CircuitClosed}
+		b.circuits[endpoint] = circuit
+	}
+	return circuit
+}
+
+// transition changes the state of a breaker, the lock must be held
+func (b *circuitBreaker) transition(endpoint string, circuit *endpointCircuit, to config.CircuitState) stateChange {
+	change := stateChange{endpoint: endpoint, from: circuit.state, to: to}
+	circuit.state = to
+	if to == config.CircuitClosed {
+		circuit.failures = 0
+	}
+	return change
+}
+
+// notify reports state changes to the callback outside the lock, so that the callback may use the client
+func (b *circuitBreaker) notify(cfg *config.CircuitBreakerConfig, changes []stateChange) {
+	if cfg.OnStateChange == nil {
+		return
+	}
+	for _, change := range changes {
+		cfg.OnStateChange(change.endpoint, change.from, change.to)
+	}
+}
+
+// isEndpointFailure reports whether the result of an attempt indicates an unhealthy endpoint
+// Connection errors and HTTP errors are endpoint failures, rejected credentials or data are not, and neither are
+// loads canceled or timed out by the caller's context
+func isEndpointFailure(err error, response *loader.LoadResponse) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if response == nil {
+		var authErr *exception.AuthError
+		return err != nil && !errors.As(err, &authErr)
+	}
+	return response.Status == loader.FAILURE && loader.IsRetryable(err, response)
+}
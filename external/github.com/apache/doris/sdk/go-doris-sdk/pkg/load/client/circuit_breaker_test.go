@@ -0,0 +1,179 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	var healthy atomic.Bool
+	var requests int32
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(successResponse))
+	})
+
+	var mu sync.Mutex
+	var changes []string
+	cfg := newTestConfig(server)
+	cfg.CircuitBreaker = &config.CircuitBreakerConfig{
+		FailureThreshold: 2,
+		ResetTimeout:     100 * time.Millisecond,
+		OnStateChange: func(endpoint string, from, to config.CircuitState) {
+			mu.Lock()
+			defer mu.Unlock()
+			if endpoint != server.URL {
+				t.Errorf("unexpected endpoint %q", endpoint)
+			}
+			changes = append(changes, fmt.Sprintf("%s->%s", from, to))
+		},
+	}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	load := func() error {
+		_, err := client.Load(strings.NewReader(`{"a":1}`))
+		return err
+	}
+
+	// Two failures open the breaker
+	for i := 0; i < 2; i++ {
+		if err := load(); err == nil {
+			t.Fatal("expected load to fail")
+		}
+	}
+	// The open endpoint is skipped without sending a request
+	if err := load(); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected errCircuitOpen, got %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Fatalf("expected 2 requests, got %d", got)
+	}
+
+	// A failed probe after the reset timeout opens the breaker again
+	time.Sleep(120 * time.Millisecond)
+	if err := load(); err == nil || errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected the probe to fail, got %v", err)
+	}
+	if err := load(); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected errCircuitOpen, got %v", err)
+	}
+
+	// A successful probe closes the breaker
+	healthy.Store(true)
+	time.Sleep(120 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if err := load(); err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if strings.Join(changes, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected state changes %v, got %v", expected, changes)
+	}
+}
+
+func TestCircuitBreakerSkipsOpenEndpoint(t *testing.T) {
+	var badRequests, goodRequests int32
+	bad := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&badRequests, 1)
+		w.Write([]byte(`{"Status":"Fail","Message":"service unavailable"}`))
+	})
+	good := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&goodRequests, 1)
+		w.Write([]byte(successResponse))
+	})
+
+	cfg := newTestConfig(bad)
+	cfg.Endpoints = []string{bad.URL, good.URL}
+	cfg.Retry = &config.Retry{MaxRetryTimes: 5, BaseIntervalMs: 1, MaxTotalTimeMs: 10000}
+	cfg.CircuitBreaker = &config.CircuitBreakerConfig{FailureThreshold: 1, ResetTimeout: time.Hour}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&badRequests); got > 1 {
+		t.Fatalf("expected the failing endpoint to be skipped after opening, got %d requests", got)
+	}
+	if got := atomic.LoadInt32(&goodRequests); got != 10 {
+		t.Fatalf("expected 10 requests to the healthy endpoint, got %d", got)
+	}
+}
+
+func TestCircuitBreakerIgnoresCanceledLoads(t *testing.T) {
+	var requests int32
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(successResponse))
+	})
+	cfg := newTestConfig(server)
+	cfg.Retry = &config.Retry{}
+	cfg.CircuitBreaker = &config.CircuitBreakerConfig{FailureThreshold: 1, ResetTimeout: time.Hour}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	// A load canceled by the caller and a load past its deadline
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := client.LoadContext(ctx, strings.NewReader(`{"a":1}`)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.LoadContext(ctx, strings.NewReader(`{"a":1}`)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// The breaker is still closed, so the next load reaches the endpoint
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("expected the load to succeed, got %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
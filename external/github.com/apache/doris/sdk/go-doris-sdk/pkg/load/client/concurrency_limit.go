@@ -0,0 +1,51 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// globalLimit is the semaphore shared by the loads of all clients, nil when unlimited
+var globalLimit atomic.Pointer[chan struct{}]
+
+// SetGlobalConcurrencyLimit caps the stream load requests in flight across all clients of the process, zero means unlimited
+// Requests already in flight when the limit changes do not count against the new limit
+func SetGlobalConcurrencyLimit(n int) {
+	if n <= 0 {
+		globalLimit.Store(nil)
+		return
+	}
+	sem := make(chan struct{}, n)
+	globalLimit.Store(&sem)
+}
+
+// acquireLoadSlot waits for a slot of the global limit or the end of the context, the returned function releases it
+func acquireLoadSlot(ctx context.Context) (func(), error) {
+	sem := globalLimit.Load()
+	if sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case *sem <- struct{}{}:
+		return func() { <-*sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,188 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+)
+
+func TestGlobalConcurrencyLimit(t *testing.T) {
+	testCases := []struct {
+		name    string
+		limit   int
+		limited bool
+	}{
+		{name: "limited", limit: 2, limited: true},
+		{name: "unlimited", limit: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var inFlight, maxInFlight atomic.Int64
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				current := inFlight.Add(1)
+				for {
+					peak := maxInFlight.Load()
+					if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+						break
+					}
+				}
+				time.Sleep(100 * time.Millisecond)
+				inFlight.Add(-1)
+				w.Write([]byte(successResponse))
+			})
+			SetGlobalConcurrencyLimit(tc.limit)
+			defer SetGlobalConcurrencyLimit(0)
+
+			// Several clients share the limit
+			var wg sync.WaitGroup
+			for i := 0; i < 3; i++ {
+				client, err := NewDorisClient(newTestConfig(server))
+				if err != nil {
+					t.Fatalf("failed to create client: %v", err)
+				}
+				for j := 0; j < 2; j++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+							t.Errorf("load failed: %v", err)
+						}
+					}()
+				}
+			}
+			wg.Wait()
+
+			// Without a limit more than 2 of the 6 loads overlap
+			if got := maxInFlight.Load(); (got <= 2) != tc.limited {
+				t.Errorf("expected the limit to apply: %t, got %d concurrent loads", tc.limited, got)
+			}
+		})
+	}
+}
+
+func TestGlobalConcurrencyLimitContext(t *testing.T) {
+	SetGlobalConcurrencyLimit(1)
+	defer SetGlobalConcurrencyLimit(0)
+
+	release, err := acquireLoadSlot(context.Background())
+	if err != nil {
+		t.Fatalf("failed to acquire a slot: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := acquireLoadSlot(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to end with the context, got %v", err)
+	}
+}
+
+func TestGlobalConcurrencyLimitKeepsHalfOpenProbe(t *testing.T) {
+	var healthy atomic.Bool
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(successResponse))
+	})
+	cfg := newTestConfig(server)
+	cfg.CircuitBreaker = &config.CircuitBreakerConfig{FailureThreshold: 1, ResetTimeout: 50 * time.Millisecond}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err == nil {
+		t.Fatal("expected the load to fail and open the breaker")
+	}
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+
+	// A load giving up while waiting for a slot must not leave the endpoint half-open without a probe
+	SetGlobalConcurrencyLimit(1)
+	defer SetGlobalConcurrencyLimit(0)
+	release, err := acquireLoadSlot(context.Background())
+	if err != nil {
+		t.Fatalf("failed to acquire a slot: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.LoadContext(ctx, strings.NewReader(`{"a":1}`)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the load to end with the context, got %v", err)
+	}
+	release()
+
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("expected the half-open probe to succeed, got %v", err)
+	}
+}
+
+func TestLoadDryRunWithinGlobalConcurrencyLimit(t *testing.T) {
+	var loads atomic.Int32
+	var traceID atomic.Value
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "_stream_load_2pc") {
+			w.Write([]byte(`{"status":"Success","msg":"transaction [42] abort successfully."}`))
+			return
+		}
+		loads.Add(1)
+		traceID.Store(r.Header.Get("X-Request-Id"))
+		w.Write([]byte(`{"TxnId":42,"Status":"Success","TwoPhaseCommit":"true","NumberTotalRows":1,"NumberLoadedRows":1}`))
+	})
+	cfg := newTestConfig(server)
+	cfg.TraceIDFunc = func() string { return "trace-1" }
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	SetGlobalConcurrencyLimit(1)
+	defer SetGlobalConcurrencyLimit(0)
+	release, err := acquireLoadSlot(context.Background())
+	if err != nil {
+		t.Fatalf("failed to acquire a slot: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.LoadDryRun(strings.NewReader(`{"a":1}`))
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if got := loads.Load(); got != 0 {
+		t.Fatalf("expected the dry run to wait for a slot, got %d loads", got)
+	}
+
+	release()
+	if err := <-done; err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if got := traceID.Load(); got != "trace-1" {
+		t.Errorf("expected the trace ID header, got %v", got)
+	}
+}
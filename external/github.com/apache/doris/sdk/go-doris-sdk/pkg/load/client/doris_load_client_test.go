@@ -0,0 +1,2096 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/exception"
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+	log "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/logger"
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/util"
+)
+
+const successResponse = `{"TxnId":1,"Label":"test","Status":"Success","NumberTotalRows":1,"NumberLoadedRows":1,"LoadBytes":10}`
+
+// newMockServer starts a stream load mock server that replies with the given handler
+// The request body is read before the handler like Doris does, otherwise the server delays closing the connection
+func newMockServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestConfig creates a client configuration targeting the given mock server
+func newTestConfig(server *httptest.Server) *config.Config {
+	return &config.Config{
+		Endpoints:   []string{server.URL},
+		User:        "root",
+		Password:    "secret_password",
+		Database:    "test_db",
+		Table:       "test_table",
+		Format:      &config.JSONFormat{Type: config.JSONObjectLine},
+		Retry:       &config.Retry{MaxRetryTimes: 0},
+		GroupCommit: config.OFF,
+		Options: map[string]string{
+			"access_token": "secret_token",
+		},
+	}
+}
+
+// captureLogs enables debug logging into a buffer and restores the defaults after the test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	log.SetLevel(log.LevelDebug)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stdout)
+		log.SetLevel(log.LevelInfo)
+	})
+	return &buf
+}
+
+func TestLoadDoesNotLogCredentials(t *testing.T) {
+	buf := captureLogs(t)
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(successResponse))
+	})
+
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Authorization") {
+		t.Fatalf("expected request headers in debug logs, got: %s", output)
+	}
+	encodedAuth := base64.StdEncoding.EncodeToString([]byte("root:secret_password"))
+	for _, secret := range []string{"secret_password", "secret_token", encodedAuth} {
+		if strings.Contains(output, secret) {
+			t.Errorf("logs contain raw secret %q: %s", secret, output)
+		}
+	}
+}
+
+func TestSlowLoadWarning(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(successResponse))
+	})
+
+	testCases := []struct {
+		name      string
+		threshold time.Duration
+		expectLog bool
+	}{
+		{name: "disabled", threshold: 0, expectLog: false},
+		{name: "below threshold", threshold: 5 * time.Second, expectLog: false},
+		{name: "past threshold", threshold: 50 * time.Millisecond, expectLog: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := captureLogs(t)
+			cfg := newTestConfig(server)
+			cfg.SlowLoadThreshold = tc.threshold
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+
+			output := buf.String()
+			warned := strings.Contains(output, "Slow load detected")
+			if warned != tc.expectLog {
+				t.Fatalf("expected slow load warning: %t, got logs: %s", tc.expectLog, output)
+			}
+			if warned && (!strings.Contains(output, "label: test") || !strings.Contains(output, "bytes: 7")) {
+				t.Errorf("slow load warning should contain label and bytes, got: %s", output)
+			}
+		})
+	}
+}
+
+func TestTraceIDPropagation(t *testing.T) {
+	buf := captureLogs(t)
+	var receivedTraceIDs []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		receivedTraceIDs = append(receivedTraceIDs, r.Header.Get(loader.TraceIDHeader))
+		w.Write([]byte(successResponse))
+	})
+
+	cfg := newTestConfig(server)
+	cfg.TraceIDFunc = func() string { return "trace-123" }
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if len(receivedTraceIDs) != 1 || receivedTraceIDs[0] != "trace-123" {
+		t.Errorf("expected X-Request-Id header trace-123, got %v", receivedTraceIDs)
+	}
+	// Both the client and the stream loader log lines of this load carry the trace ID
+	for _, message := range []string{"Starting stream load operation", "Stream Load Response", "completed successfully on attempt 1"} {
+		found := false
+		for _, line := range strings.Split(buf.String(), "\n") {
+			if strings.Contains(line, message) {
+				found = true
+				if !strings.Contains(line, "trace_id=trace-123") {
+					t.Errorf("log line should contain the trace ID: %s", line)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected log line containing %q, got: %s", message, buf.String())
+		}
+	}
+}
+
+func TestNoTraceIDByDefault(t *testing.T) {
+	buf := captureLogs(t)
+	var header http.Header
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(successResponse))
+	})
+
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if _, ok := header[loader.TraceIDHeader]; ok {
+		t.Errorf("X-Request-Id header should not be set without TraceIDFunc")
+	}
+	if strings.Contains(buf.String(), "trace_id") {
+		t.Errorf("logs should not contain trace_id without TraceIDFunc: %s", buf.String())
+	}
+}
+
+func TestApplyJitter(t *testing.T) {
+	interval := time.Second
+	for i := 0; i < 100; i++ {
+		jittered := applyJitter(interval)
+		if jittered < interval/2 || jittered > interval {
+			t.Fatalf("jittered interval %v out of range [%v, %v]", jittered, interval/2, interval)
+		}
+	}
+	if applyJitter(0) != 0 {
+		t.Errorf("zero interval should stay zero")
+	}
+}
+
+func TestCalculateBackoffIntervalCap(t *testing.T) {
+	const baseIntervalMs, maxTotalTimeMs = int64(1000), int64(3600000)
+	for attempt := 1; attempt <= 10; attempt++ {
+		if interval := calculateBackoffInterval(attempt, baseIntervalMs, 3000, maxTotalTimeMs, 0); interval > 3*time.Second {
+			t.Errorf("attempt %d: interval %v exceeds the 3s cap", attempt, interval)
+		}
+	}
+	uncapped := calculateBackoffInterval(5, baseIntervalMs, 0, maxTotalTimeMs, 0)
+	if uncapped != 16*time.Second {
+		t.Errorf("expected uncapped interval 16s, got %v", uncapped)
+	}
+}
+
+func TestLoadDryRun(t *testing.T) {
+	var loadHeaders http.Header
+	var txnOperations []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/test_db/test_table/_stream_load":
+			loadHeaders = r.Header
+			w.Write([]byte(`{"TxnId":42,"Status":"Success","TwoPhaseCommit":"true","NumberTotalRows":2,"NumberLoadedRows":2}`))
+		case "/api/test_db/_stream_load_2pc":
+			txnOperations = append(txnOperations, r.Header.Get("txn_operation")+":"+r.Header.Get("txn_id"))
+			w.Write([]byte(`{"status":"Success","msg":"transaction [42] abort successfully."}`))
+		default:
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+		}
+	})
+
+	cfg := newTestConfig(server)
+	cfg.GroupCommit = config.ASYNC
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	response, err := client.LoadDryRun(strings.NewReader("{\"a\":1}\n{\"a\":2}\n"))
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+
+	if response.Resp.NumberLoadedRows != 2 {
+		t.Errorf("expected parsed response with 2 loaded rows, got %d", response.Resp.NumberLoadedRows)
+	}
+	if loadHeaders.Get("two_phase_commit") != "true" {
+		t.Errorf("dry run should enable two-phase commit")
+	}
+	if loadHeaders.Get("group_commit") != "" {
+		t.Errorf("dry run should disable group commit")
+	}
+	if len(txnOperations) != 1 || txnOperations[0] != "abort:42" {
+		t.Errorf("expected only an abort of transaction 42, got %v", txnOperations)
+	}
+	if cfg.GroupCommit != config.ASYNC || cfg.Options["two_phase_commit"] != "" {
+		t.Errorf("dry run should not modify the client configuration")
+	}
+}
+
+func TestLoadDryRunFailureSkipsAbort(t *testing.T) {
+	abortCalled := false
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "_stream_load_2pc") {
+			abortCalled = true
+		}
+		w.Write([]byte(`{"TxnId":42,"Status":"Fail","Message":"[DATA_QUALITY_ERROR]too many filtered rows","NumberFilteredRows":2}`))
+	})
+
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	response, err := client.LoadDryRun(strings.NewReader(`{"a":1}`))
+
+	var dataQualityErr *exception.DataQualityError
+	if !errors.As(err, &dataQualityErr) {
+		t.Errorf("expected DataQualityError, got %T: %v", err, err)
+	}
+	if response == nil || response.Resp.NumberFilteredRows != 2 {
+		t.Errorf("expected the failed response to be returned, got %+v", response)
+	}
+	if abortCalled {
+		t.Errorf("abort should not be called when the pre-commit failed")
+	}
+}
+
+func TestLoadDryRunWithFixedLabel(t *testing.T) {
+	testCases := []struct {
+		name          string
+		alwaysExists  bool
+		expectAborted []string
+		expectErr     bool
+	}{
+		{name: "own label", expectAborted: []string{"42"}},
+		{name: "no pre-committed transaction", alwaysExists: true, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var labels, aborted []string
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "_stream_load_2pc") {
+					aborted = append(aborted, r.Header.Get("txn_id"))
+					w.Write([]byte(`{"status":"Success","msg":"transaction [42] abort successfully."}`))
+					return
+				}
+				label := r.Header.Get("label")
+				labels = append(labels, label)
+				if tc.alwaysExists || label == "l1" {
+					fmt.Fprintf(w, `{"Label":%q,"Status":"Label Already Exists","ExistingJobStatus":"FINISHED"}`, label)
+					return
+				}
+				w.Write([]byte(`{"TxnId":42,"Status":"Success","TwoPhaseCommit":"true","NumberTotalRows":1,"NumberLoadedRows":1}`))
+			})
+			cfg := newTestConfig(server)
+			cfg.Label = "l1"
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.LoadDryRun(strings.NewReader(`{"a":1}`))
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if len(labels) != 1 || !strings.HasPrefix(labels[0], "dryrun_") {
+				t.Errorf("expected a single load with a generated dryrun label, got %v", labels)
+			}
+			if strings.Join(aborted, ",") != strings.Join(tc.expectAborted, ",") {
+				t.Errorf("expected aborted transactions %v, got %v", tc.expectAborted, aborted)
+			}
+		})
+	}
+}
+
+// seekableReader hides the concrete reader type, so that http.NewRequest cannot set GetBody by itself
+type seekableReader struct {
+	io.ReadSeeker
+}
+
+func TestLoadFollowsRedirectWithBody(t *testing.T) {
+	var receivedBody, receivedAuth string
+	backend := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+		receivedAuth = r.Header.Get("Authorization")
+		w.Write([]byte(successResponse))
+	})
+	// Redirect to another host name like FE does to a BE node
+	backendURL := strings.Replace(backend.URL, "127.0.0.1", "localhost", 1)
+	frontend := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Expect") != "100-continue" {
+			t.Errorf("expected Expect: 100-continue header")
+		}
+		http.Redirect(w, r, backendURL+r.URL.Path, http.StatusTemporaryRedirect)
+	})
+
+	client, err := NewDorisClient(newTestConfig(frontend))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	data := "{\"a\":1}\n{\"a\":2}\n"
+	if _, err := client.Load(seekableReader{strings.NewReader(data)}); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if receivedBody != data {
+		t.Errorf("expected body %q at the final handler, got %q", data, receivedBody)
+	}
+	expectedAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("root:secret_password"))
+	if receivedAuth != expectedAuth {
+		t.Errorf("expected credentials to be kept on redirect, got %q", receivedAuth)
+	}
+}
+
+func TestOnRedirect(t *testing.T) {
+	backend := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(successResponse))
+	})
+	backendURL := strings.Replace(backend.URL, "127.0.0.1", "localhost", 1)
+	frontend := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, backendURL+r.URL.Path, http.StatusTemporaryRedirect)
+	})
+
+	var redirects []string
+	cfg := newTestConfig(frontend)
+	cfg.OnRedirect = func(from, to string) {
+		redirects = append(redirects, from+"->"+to)
+	}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	expected := strings.TrimPrefix(frontend.URL, "http://") + "->" + strings.TrimPrefix(backendURL, "http://")
+	if len(redirects) != 1 || redirects[0] != expected {
+		t.Errorf("expected redirect %q, got %v", expected, redirects)
+	}
+}
+
+func TestOnTrace(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(successResponse))
+	})
+
+	var traces []config.LoadTrace
+	cfg := newTestConfig(server)
+	// Use a host name so that the DNS phase happens too
+	cfg.Endpoints = []string{strings.Replace(server.URL, "127.0.0.1", "localhost", 1)}
+	cfg.OnTrace = func(trace config.LoadTrace) {
+		traces = append(traces, trace)
+	}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if len(traces) != 1 {
+		t.Fatalf("expected 1 trace, got %d", len(traces))
+	}
+	trace := traces[0]
+	if trace.Attempt != 1 || trace.Label == "" || !strings.HasPrefix(trace.Endpoint, "localhost:") {
+		t.Errorf("unexpected trace metadata: %+v", trace)
+	}
+	if trace.ReusedConn {
+		t.Errorf("first request to a new server should not reuse a connection")
+	}
+	if trace.DNS <= 0 || trace.Connect <= 0 || trace.FirstByte <= 0 || trace.Total < trace.FirstByte {
+		t.Errorf("expected nonzero phase timings, got %+v", trace)
+	}
+}
+
+func TestLoadTo(t *testing.T) {
+	var paths []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(successResponse))
+	})
+
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.LoadTo("other_db", "other_table", strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	// The configured target is used again by Load
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	expected := []string{"/api/other_db/other_table/_stream_load", "/api/test_db/test_table/_stream_load"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestExtraHeaders(t *testing.T) {
+	var headers []http.Header
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.Write([]byte(successResponse))
+	})
+
+	cfg := newTestConfig(server)
+	cfg.ExtraHeaders = map[string]string{"X-Tenant-Id": "tenant-a", "x-route": "zone-1"}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if _, err := client.LoadWithHeaders(map[string]string{"X-Tenant-Id": "tenant-b", "X-Priority": "high"}, strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load with headers failed: %v", err)
+	}
+
+	expected := []map[string]string{
+		{"X-Tenant-Id": "tenant-a", "X-Route": "zone-1", "X-Priority": ""},
+		{"X-Tenant-Id": "tenant-b", "X-Route": "zone-1", "X-Priority": "high"},
+	}
+	if len(headers) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(headers))
+	}
+	for i, want := range expected {
+		for name, value := range want {
+			if got := headers[i].Get(name); got != value {
+				t.Errorf("request %d: expected %s %q, got %q", i, name, value, got)
+			}
+		}
+		if auth := headers[i].Get("Authorization"); auth != loader.BasicAuthHeader(cfg.User, cfg.Password) {
+			t.Errorf("request %d: expected the SDK Authorization header, got %q", i, auth)
+		}
+	}
+}
+
+func TestLoadWithHeadersReserved(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for _, name := range []string{"Authorization", "content-length", "Expect", "label"} {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.LoadWithHeaders(map[string]string{name: "x"}, strings.NewReader(`{"a":1}`))
+			var validationErr *config.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadRaw(t *testing.T) {
+	var bodies [][]byte
+	var headers []http.Header
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, body)
+		headers = append(headers, r.Header.Clone())
+		w.Write([]byte(successResponse))
+	})
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		format     config.Format
+		data       string
+		wantFormat string
+	}{
+		{name: "csv without trailing delimiter", format: &config.CSVFormat{ColumnSeparator: "|", LineDelimiter: "\r\n"},
+			data: "1|a\r\n2|b", wantFormat: "csv"},
+		{name: "json array", format: &config.JSONFormat{Type: config.JSONArray}, data: "[{\"a\":1},\n{\"a\":2}]\n\n", wantFormat: "json"},
+		{name: "json lines with blank lines", format: &config.JSONFormat{Type: config.JSONObjectLine}, data: "{\"a\":1}\n\n{\"a\":2}", wantFormat: "json"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bodies, headers = nil, nil
+			if _, err := client.LoadRaw(strings.NewReader(tc.data), tc.format); err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+			if len(bodies) != 1 || !bytes.Equal(bodies[0], []byte(tc.data)) {
+				t.Fatalf("expected the body %q sent verbatim, got %q", tc.data, bodies)
+			}
+			if format := headers[0].Get("format"); format != tc.wantFormat {
+				t.Errorf("expected format %q, got %q", tc.wantFormat, format)
+			}
+		})
+	}
+
+	// The configured format is left unchanged
+	if _, ok := client.currentConfig().Format.(*config.JSONFormat); !ok {
+		t.Errorf("expected the configured format to be kept, got %T", client.currentConfig().Format)
+	}
+
+	for _, format := range []config.Format{nil, &config.CSVFormat{}} {
+		var validationErr *config.ValidationError
+		if _, err := client.LoadRaw(strings.NewReader("1,a"), format); !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error for format %v, got %v", format, err)
+		}
+	}
+}
+
+func TestLoadWithGroupCommit(t *testing.T) {
+	var headers []http.Header
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.Write([]byte(successResponse))
+	})
+
+	testCases := []struct {
+		name            string
+		configured      config.GroupCommitMode
+		options         map[string]string
+		mode            config.GroupCommitMode
+		wantGroupCommit string
+	}{
+		{name: "async over off", configured: config.OFF, mode: config.ASYNC, wantGroupCommit: "async_mode"},
+		{name: "sync over async", configured: config.ASYNC, mode: config.SYNC, wantGroupCommit: "sync_mode"},
+		{name: "off over sync", configured: config.SYNC, mode: config.OFF, wantGroupCommit: ""},
+		{name: "off over options", configured: config.OFF, options: map[string]string{"group_commit": "async_mode"}, mode: config.OFF, wantGroupCommit: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			headers = nil
+			cfg := newTestConfig(server)
+			cfg.GroupCommit = tc.configured
+			cfg.Options = tc.options
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			if _, err := client.LoadWithGroupCommit(tc.mode, strings.NewReader(`{"a":1}`)); err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+			// The configured mode is used again by Load
+			if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+
+			if len(headers) != 2 {
+				t.Fatalf("expected 2 requests, got %d", len(headers))
+			}
+			if got := headers[0].Get("group_commit"); got != tc.wantGroupCommit {
+				t.Errorf("expected group_commit %q, got %q", tc.wantGroupCommit, got)
+			}
+			if hasLabel := headers[0].Get("label") != ""; hasLabel != (tc.wantGroupCommit == "") {
+				t.Errorf("expected a label only without group commit, got label %q", headers[0].Get("label"))
+			}
+			configuredGroupCommit := tc.configured != config.OFF || tc.options != nil
+			if hasGroupCommit := headers[1].Get("group_commit") != ""; hasGroupCommit != configuredGroupCommit {
+				t.Errorf("expected the configured group commit on the next load, got %q", headers[1].Get("group_commit"))
+			}
+		})
+	}
+}
+
+func TestLoadWithGroupCommitStrictLabelPolicy(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	cfg := newTestConfig(server)
+	cfg.StrictLabelPolicy = true
+	cfg.LabelPrefix = "app"
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.LoadWithGroupCommit(config.ASYNC, strings.NewReader(`{"a":1}`))
+	var validationErr *config.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+}
+
+func TestEffectiveHeaders(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	cfg := newTestConfig(server)
+	cfg.Format = &config.CSVFormat{ColumnSeparator: "\t", LineDelimiter: "\n"}
+	cfg.GroupCommit = config.ASYNC
+	cfg.Options = map[string]string{"max_filter_ratio": "0.1"}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	headers := client.EffectiveHeaders()
+	expected := map[string]string{
+		"Authorization":    util.RedactedValue,
+		"Format":           "csv",
+		"Column_separator": `\t`,
+		"Line_delimiter":   `\n`,
+		"Max_filter_ratio": "0.1",
+		"Group_commit":     "async_mode",
+	}
+	for name, value := range expected {
+		if headers[name] != value {
+			t.Errorf("expected %s %q, got %q", name, value, headers[name])
+		}
+	}
+	if _, ok := headers["Label"]; ok {
+		t.Errorf("expected no label, got %q", headers["Label"])
+	}
+}
+
+func TestCredentialsFromEnv(t *testing.T) {
+	var auths []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		w.Write([]byte(successResponse))
+	})
+	t.Setenv("DORIS_TEST_USER", "loader")
+	t.Setenv("DORIS_TEST_PASSWORD", "s3cret")
+
+	cfg := newTestConfig(server)
+	cfg.User = "${ENV:DORIS_TEST_USER}"
+	cfg.Password = "${ENV:DORIS_TEST_PASSWORD}"
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	t.Setenv("DORIS_TEST_PASSWORD", "rotated")
+	if err := client.UpdateCredentials("${ENV:DORIS_TEST_USER}", "${ENV:DORIS_TEST_PASSWORD}"); err != nil {
+		t.Fatalf("failed to update credentials: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	expected := []string{loader.BasicAuthHeader("loader", "s3cret"), loader.BasicAuthHeader("loader", "rotated")}
+	if strings.Join(auths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected authorizations %v, got %v", expected, auths)
+	}
+}
+
+func TestCredentialsFromMissingEnv(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	cfg := newTestConfig(server)
+	cfg.Password = "${ENV:DORIS_TEST_MISSING_PASSWORD}"
+	_, err := NewDorisClient(cfg)
+	if err == nil || !strings.Contains(err.Error(), "password: environment variable DORIS_TEST_MISSING_PASSWORD is not set") {
+		t.Fatalf("expected a missing environment variable error, got %v", err)
+	}
+}
+
+func TestCredentialsFromEndpoint(t *testing.T) {
+	testCases := []struct {
+		name         string
+		user         string
+		password     string
+		expectedAuth string
+	}{
+		{name: "embedded credentials", expectedAuth: loader.BasicAuthHeader("loader", "p@ss")},
+		{name: "configured credentials take precedence", user: "root", password: "secret",
+			expectedAuth: loader.BasicAuthHeader("root", "secret")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var auth string
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				w.Write([]byte(successResponse))
+			})
+			cfg := newTestConfig(server)
+			cfg.Endpoints = []string{strings.Replace(server.URL, "http://", "http://loader:p%40ss@", 1)}
+			cfg.User = tc.user
+			cfg.Password = tc.password
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			if endpoints := client.config.Endpoints; len(endpoints) != 1 || endpoints[0] != server.URL {
+				t.Errorf("expected the credentials stripped from the endpoint, got %v", endpoints)
+			}
+			if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+			if auth != tc.expectedAuth {
+				t.Errorf("expected authorization %q, got %q", tc.expectedAuth, auth)
+			}
+		})
+	}
+}
+
+func TestCredentialsStrippedFromNewEndpoints(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(successResponse))
+	})
+	withCredentials := strings.Replace(server.URL, "http://", "http://loader:secret@", 1)
+	checkStripped := func(t *testing.T, endpoints []string) {
+		t.Helper()
+		if len(endpoints) != 1 || endpoints[0] != server.URL {
+			t.Errorf("expected the credentials stripped from the endpoints, got %v", endpoints)
+		}
+	}
+
+	t.Run("UpdateEndpoints", func(t *testing.T) {
+		client, err := NewDorisClient(newTestConfig(server))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		if err := client.UpdateEndpoints([]string{withCredentials}); err != nil {
+			t.Fatalf("failed to update endpoints: %v", err)
+		}
+		checkStripped(t, client.currentConfig().Endpoints)
+	})
+
+	t.Run("EndpointProvider", func(t *testing.T) {
+		cfg := newTestConfig(server)
+		cfg.EndpointProvider = func() ([]string, error) { return []string{withCredentials}, nil }
+		client, err := NewDorisClient(cfg)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		checkStripped(t, client.currentConfig().Endpoints)
+
+		// Refreshed endpoints are stripped too
+		client.endpoints.refreshedAt = time.Time{}
+		checkStripped(t, client.currentConfig().Endpoints)
+	})
+}
+
+func TestLoadFile(t *testing.T) {
+	var bodies []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Write([]byte(`{"Status":"Fail","Message":"backend unavailable"}`))
+			return
+		}
+		w.Write([]byte(successResponse))
+	})
+
+	data := "{\"a\":1}\n{\"a\":2}\n"
+	path := filepath.Join(t.TempDir(), "data.json")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("failed to write data file: %v", err)
+	}
+
+	cfg := newTestConfig(server)
+	cfg.Retry = &config.Retry{MaxRetryTimes: 1, BaseIntervalMs: 1}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.LoadFile(path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	// The retry sends the whole file again
+	if len(bodies) != 2 || bodies[0] != data || bodies[1] != data {
+		t.Errorf("expected the file content in both attempts, got %q", bodies)
+	}
+	// The file is closed after the load
+	if fds, err := os.ReadDir("/proc/self/fd"); err == nil {
+		for _, fd := range fds {
+			if target, _ := os.Readlink(filepath.Join("/proc/self/fd", fd.Name())); target == path {
+				t.Errorf("expected the data file to be closed")
+			}
+		}
+	}
+
+	if _, err := client.LoadFile(filepath.Join(t.TempDir(), "missing.json")); err == nil || !strings.Contains(err.Error(), "failed to open data file") {
+		t.Errorf("expected an error opening a missing file, got %v", err)
+	}
+}
+
+func TestEmptyLoad(t *testing.T) {
+	testCases := []struct {
+		name      string
+		format    config.Format
+		data      string
+		allow     *bool
+		sentEmpty bool
+	}{
+		{name: "object line", format: &config.JSONFormat{Type: config.JSONObjectLine}, data: ""},
+		{name: "object line whitespace", format: &config.JSONFormat{Type: config.JSONObjectLine}, data: " \n\n"},
+		{name: "array", format: &config.JSONFormat{Type: config.JSONArray}, data: ""},
+		{name: "empty array", format: &config.JSONFormat{Type: config.JSONArray}, data: " [ \n]\n"},
+		{name: "not allowed", format: &config.JSONFormat{Type: config.JSONObjectLine}, data: "", allow: new(bool), sentEmpty: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int32
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				w.Write([]byte(successResponse))
+			})
+			cfg := newTestConfig(server)
+			cfg.Format = tc.format
+			cfg.AllowEmptyLoad = tc.allow
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			response, err := client.Load(strings.NewReader(tc.data))
+			if err != nil || response.Status != loader.SUCCESS {
+				t.Fatalf("expected a successful load, got %+v, %v", response, err)
+			}
+			if sent := atomic.LoadInt32(&requests) > 0; sent != tc.sentEmpty {
+				t.Fatalf("expected the empty load sent: %t, got %t", tc.sentEmpty, sent)
+			}
+			if !tc.sentEmpty && (response.Resp.NumberLoadedRows != 0 || response.Attempts != 0) {
+				t.Errorf("expected no rows and no attempts, got %+v", response)
+			}
+		})
+	}
+}
+
+func TestLoadToInvalidNames(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		database string
+		table    string
+	}{
+		{name: "empty database", database: "", table: "t"},
+		{name: "empty table", database: "db", table: ""},
+		{name: "slash", database: "db", table: "t/_stream_load"},
+		{name: "query", database: "db?x=1", table: "t"},
+		{name: "whitespace", database: "db", table: "my table"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.LoadTo(tc.database, tc.table, strings.NewReader(`{"a":1}`))
+			var validationErr *config.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadResponseLabelOnFailure(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Status":"Fail","Message":"[DATA_QUALITY_ERROR]too many filtered rows","NumberFilteredRows":1}`))
+	})
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+
+	testCases := []struct {
+		name      string
+		server    *httptest.Server
+		retriable bool
+	}{
+		{name: "failure response", server: server},
+		{name: "connection error", server: closedServer, retriable: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(tc.server)
+			cfg.LabelPrefix = "trace_me"
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			response, err := client.Load(strings.NewReader(`{"a":1}`))
+			if err == nil {
+				t.Fatal("expected load to fail")
+			}
+			if response == nil || response.Status != loader.FAILURE {
+				t.Fatalf("expected failure response, got %+v", response)
+			}
+			if !strings.HasPrefix(response.Label, "trace_me_test_db_test_table_") {
+				t.Fatalf("expected generated label, got %q", response.Label)
+			}
+			if response.Err != err || response.IsRetriable() != tc.retriable {
+				t.Errorf("expected the load error and retriable %t, got %v and %t", tc.retriable, response.Err, response.IsRetriable())
+			}
+		})
+	}
+}
+
+func TestRetryableMessages(t *testing.T) {
+	testCases := []struct {
+		name             string
+		failure          string
+		retryable        []string
+		expectedAttempts int32
+	}{
+		{
+			name:             "publish timeout",
+			failure:          `{"Status":"Publish Timeout","Message":"transaction commit successfully, but data will be visible later"}`,
+			retryable:        []string{"Publish Timeout"},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "configured message",
+			failure:          `{"Status":"Fail","Message":"[E-235]too many versions"}`,
+			retryable:        []string{"publish timeout", "too many versions"},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "message not configured",
+			failure:          `{"Status":"Fail","Message":"[E-235]too many versions"}`,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "data error",
+			failure:          `{"Status":"Fail","Message":"[DATA_QUALITY_ERROR]too many filtered rows","NumberFilteredRows":1}`,
+			retryable:        []string{"publish timeout"},
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int32
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					w.Write([]byte(tc.failure))
+					return
+				}
+				w.Write([]byte(successResponse))
+			})
+			cfg := newTestConfig(server)
+			cfg.Retry = &config.Retry{MaxRetryTimes: 2, BaseIntervalMs: 1, MaxTotalTimeMs: 1000}
+			cfg.RetryableMessages = tc.retryable
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			response, err := client.Load(strings.NewReader(`{"a":1}`))
+			if got := atomic.LoadInt32(&attempts); got != tc.expectedAttempts {
+				t.Fatalf("expected %d attempts, got %d", tc.expectedAttempts, got)
+			}
+			if succeeded := err == nil && response.Status == loader.SUCCESS; succeeded != (tc.expectedAttempts > 1) {
+				t.Errorf("unexpected result: %+v, %v", response, err)
+			}
+		})
+	}
+}
+
+func TestLoadResponseAttemptsAndDuration(t *testing.T) {
+	var requests int32
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Write([]byte(`{"Status":"Fail","Message":"backend unavailable"}`))
+			return
+		}
+		w.Write([]byte(successResponse))
+	})
+	cfg := newTestConfig(server)
+	cfg.Retry = &config.Retry{MaxRetryTimes: 3, BaseIntervalMs: 1, MaxTotalTimeMs: 1000}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	response, err := client.Load(strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if response.Attempts != 2 {
+		t.Errorf("expected 2 attempts for one retry, got %d", response.Attempts)
+	}
+	if response.Duration <= 0 {
+		t.Errorf("expected a nonzero duration, got %v", response.Duration)
+	}
+
+	// No request is sent once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	response, _ = client.LoadContext(ctx, strings.NewReader(`{"a":1}`))
+	if response.Attempts != 0 || response.Duration <= 0 {
+		t.Errorf("expected no attempts and a nonzero duration, got %d and %v", response.Attempts, response.Duration)
+	}
+}
+
+func TestLoadWithTimeout(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// Stall until the client gives up
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	})
+	cfg := newTestConfig(server)
+	cfg.Retry = &config.Retry{MaxRetryTimes: 3, BaseIntervalMs: 10, MaxTotalTimeMs: 60000}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	response, err := client.LoadWithTimeout(strings.NewReader(`{"a":1}`), 200*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected the load to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the load to give up at the deadline, took %v", elapsed)
+	}
+	if response == nil || response.Status != loader.FAILURE {
+		t.Errorf("expected failure response, got %+v", response)
+	}
+}
+
+func TestLoadContextSharedDeadline(t *testing.T) {
+	var attempts int32
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Write([]byte(`{"Status":"Fail","Message":"backend unavailable"}`))
+	})
+	cfg := newTestConfig(server)
+	cfg.Retry = &config.Retry{MaxRetryTimes: 10, BaseIntervalMs: 50, MaxTotalTimeMs: 60000}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	// The first load uses up the budget: attempts at 0, 50ms and 150ms, the next backoff of 200ms passes the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.LoadContext(ctx, strings.NewReader(`{"a":1}`)); err == nil {
+		t.Fatal("expected the load to fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the deadline to stop retrying, took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("expected 3 attempts within the deadline, got %d", got)
+	}
+
+	// Later loads sharing the context give up without sending anything
+	<-ctx.Done()
+	response, err := client.LoadContext(ctx, strings.NewReader(`{"a":2}`))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+	if response == nil || response.Status != loader.FAILURE {
+		t.Errorf("expected failure response, got %+v", response)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("expected no further attempts, got %d", got)
+	}
+}
+
+func TestLoadContextCancelDuringBackoff(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Status":"Fail","Message":"backend unavailable"}`))
+	})
+	cfg := newTestConfig(server)
+	cfg.Retry = &config.Retry{MaxRetryTimes: 3, BaseIntervalMs: 10000, MaxTotalTimeMs: 60000}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	if _, err := client.LoadContext(ctx, strings.NewReader(`{"a":1}`)); err == nil {
+		t.Fatal("expected the load to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected cancel to interrupt the backoff, took %v", elapsed)
+	}
+}
+
+// newClientCertificate creates a self-signed client certificate and writes it and its key as PEM files
+func newClientCertificate(t *testing.T) (certificate *x509.Certificate, certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "doris-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certificate, _ = x509.ParseCertificate(der)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	return certificate, certFile, keyFile
+}
+
+func TestClientCertificate(t *testing.T) {
+	certificate, certFile, keyFile := newClientCertificate(t)
+	loaded, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("failed to load certificate: %v", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(certificate)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(successResponse))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name    string
+		modify  func(cfg *config.Config)
+		wantErr bool
+	}{
+		{name: "no certificate", modify: func(cfg *config.Config) {}, wantErr: true},
+		{name: "certificate files", modify: func(cfg *config.Config) {
+			cfg.ClientCertFile = certFile
+			cfg.ClientKeyFile = keyFile
+		}},
+		{name: "loaded certificate", modify: func(cfg *config.Config) { cfg.ClientCertificate = &loaded }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(server)
+			tc.modify(cfg)
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			_, err = client.Load(strings.NewReader(`{"a":1}`))
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	// Unreadable files are reported when creating the client
+	cfg := newTestConfig(server)
+	cfg.ClientCertFile = filepath.Join(t.TempDir(), "missing.crt")
+	cfg.ClientKeyFile = keyFile
+	if _, err := NewDorisClient(cfg); err == nil || !strings.Contains(err.Error(), "client certificate") {
+		t.Errorf("expected a client certificate error, got %v", err)
+	}
+}
+
+func TestProxy(t *testing.T) {
+	var proxied []string
+	proxy := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// Requests to a proxy carry the absolute URL of the target
+		proxied = append(proxied, r.URL.String())
+		w.Write([]byte(successResponse))
+	})
+
+	cfg := newTestConfig(proxy)
+	cfg.Endpoints = []string{"http://doris-fe.invalid:8030"}
+	cfg.Proxy = proxy.URL
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load through the proxy failed: %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://doris-fe.invalid:8030/api/test_db/test_table/_stream_load" {
+		t.Errorf("expected the stream load to go through the proxy, got %q", proxied)
+	}
+}
+
+func TestIsolatedTransport(t *testing.T) {
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(successResponse))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name      string
+		isolated  bool
+		wantConns int32
+	}{
+		{name: "shared transport", isolated: false, wantConns: 1},
+		{name: "isolated transports", isolated: true, wantConns: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			util.GetHttpClient().CloseIdleConnections()
+			atomic.StoreInt32(&connections, 0)
+			for i := 0; i < 2; i++ {
+				cfg := newTestConfig(server)
+				cfg.IsolatedTransport = tc.isolated
+				client, err := NewDorisClient(cfg)
+				if err != nil {
+					t.Fatalf("failed to create client: %v", err)
+				}
+				if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+					t.Fatalf("load failed: %v", err)
+				}
+			}
+			if got := atomic.LoadInt32(&connections); got != tc.wantConns {
+				t.Errorf("expected %d connections, got %d", tc.wantConns, got)
+			}
+		})
+	}
+}
+
+func TestHTTPClientOption(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(successResponse))
+	})
+
+	var requests int32
+	cfg := newTestConfig(server)
+	cfg.HTTPClient = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected the load to use the given HTTP client, got %d requests through it", got)
+	}
+}
+
+func TestLogErrorURLSample(t *testing.T) {
+	var fetches int32
+	var errorURL string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/_load_error_log" {
+			atomic.AddInt32(&fetches, 1)
+			w.Write([]byte("Reason: column count mismatch. src line: [a,b,c]"))
+			return
+		}
+		fmt.Fprintf(w, `{"Label":"test","Status":"Success","NumberTotalRows":2,"NumberLoadedRows":1,"NumberFilteredRows":1,"ErrorURL":%q}`, errorURL)
+	})
+	errorURL = server.URL + "/api/_load_error_log?file=error_log"
+
+	testCases := []struct {
+		name        string
+		enabled     bool
+		wantFetches int32
+	}{
+		{name: "disabled by default", enabled: false, wantFetches: 0},
+		{name: "enabled and rate limited", enabled: true, wantFetches: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := captureLogs(t)
+			atomic.StoreInt32(&fetches, 0)
+			cfg := newTestConfig(server)
+			cfg.LogErrorURLSample = tc.enabled
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			for i := 0; i < 2; i++ {
+				if _, err := client.Load(strings.NewReader("a,b,c")); err != nil {
+					t.Fatalf("load failed: %v", err)
+				}
+			}
+			if got := atomic.LoadInt32(&fetches); got != tc.wantFetches {
+				t.Errorf("expected %d error details fetches, got %d", tc.wantFetches, got)
+			}
+			logged := strings.Contains(buf.String(), "column count mismatch")
+			if logged != tc.enabled {
+				t.Errorf("expected the sample to be logged: %t, got logs: %s", tc.enabled, buf.String())
+			}
+		})
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHTTPTimeoutShorterThanLoadTimeoutWarning(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	loadTimeout := 600
+
+	testCases := []struct {
+		name        string
+		httpTimeout time.Duration
+		expectLog   bool
+	}{
+		{name: "default http timeout", httpTimeout: 0, expectLog: true},
+		{name: "shorter http timeout", httpTimeout: time.Minute, expectLog: true},
+		{name: "longer http timeout", httpTimeout: 11 * time.Minute, expectLog: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := captureLogs(t)
+			cfg := newTestConfig(server)
+			cfg.LoadTimeoutSeconds = &loadTimeout
+			cfg.HTTPTimeout = tc.httpTimeout
+			if _, err := NewDorisClient(cfg); err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			warned := strings.Contains(buf.String(), "is shorter than the load timeout")
+			if warned != tc.expectLog {
+				t.Fatalf("expected warning: %t, got logs: %s", tc.expectLog, buf.String())
+			}
+		})
+	}
+}
+
+func TestHTTPTimeout(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(successResponse))
+	})
+
+	cfg := newTestConfig(server)
+	cfg.HTTPTimeout = 50 * time.Millisecond
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	var connErr *exception.ConnectionError
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); !errors.As(err, &connErr) {
+		t.Fatalf("expected the request to time out, got %v", err)
+	}
+}
+
+func TestUpdateCredentials(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var users []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		users = append(users, user)
+		if len(users) == 1 {
+			// Keep the first attempt in flight while the credentials are rotated, then make it retry
+			close(started)
+			<-release
+			w.Write([]byte(`{"Status":"Fail","Message":"service unavailable"}`))
+			return
+		}
+		w.Write([]byte(successResponse))
+	})
+
+	cfg := newTestConfig(server)
+	cfg.Retry = &config.Retry{MaxRetryTimes: 1, BaseIntervalMs: 1, MaxTotalTimeMs: 1000}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := client.Load(strings.NewReader(`{"a":1}`))
+		done <- err
+	}()
+	<-started
+	if err := client.UpdateCredentials("rotated", "new_password"); err != nil {
+		t.Fatalf("failed to update credentials: %v", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("in-flight load failed: %v", err)
+	}
+
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	// The in-flight load retries with the credentials it started with
+	expected := []string{"root", "root", "rotated"}
+	if strings.Join(users, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected users %v, got %v", expected, users)
+	}
+	if cfg.User != "root" {
+		t.Fatalf("the caller's configuration should not be modified, got user %q", cfg.User)
+	}
+}
+
+func TestUpdateRejectsInvalidConfig(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(successResponse))
+	})
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if err := client.UpdateCredentials("", "password"); err == nil {
+		t.Fatal("expected empty user to be rejected")
+	}
+	if err := client.UpdateEndpoints([]string{"127.0.0.1:8030"}); err == nil {
+		t.Fatal("expected endpoint without scheme to be rejected")
+	}
+	// The previous configuration is kept
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+}
+
+func TestUpdateEndpoints(t *testing.T) {
+	var hits [2]int32
+	servers := make([]*httptest.Server, 2)
+	for i := range servers {
+		i := i
+		servers[i] = newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			hits[i]++
+			w.Write([]byte(successResponse))
+		})
+	}
+
+	client, err := NewDorisClient(newTestConfig(servers[0]))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if err := client.UpdateEndpoints([]string{servers[1].URL}); err != nil {
+		t.Fatalf("failed to update endpoints: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if hits[0] != 0 || hits[1] != 1 {
+		t.Fatalf("expected the load to go to the new endpoint, got hits %v", hits)
+	}
+}
+
+func TestAllEndpointsFailed(t *testing.T) {
+	var hits [3]int32
+	servers := make([]*httptest.Server, 3)
+	for i := range servers {
+		i := i
+		servers[i] = newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&hits[i], 1)
+			w.Write([]byte(`{"Status":"Fail","Message":"backend unavailable"}`))
+		})
+	}
+	cfg := newTestConfig(servers[0])
+	cfg.Endpoints = []string{servers[0].URL, servers[1].URL, servers[2].URL}
+	cfg.Retry = &config.Retry{MaxRetryTimes: 2, BaseIntervalMs: 1, MaxTotalTimeMs: 1000}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.Load(strings.NewReader(`{"a":1}`))
+	var allFailed *exception.AllEndpointsFailedError
+	if !errors.As(err, &allFailed) {
+		t.Fatalf("expected AllEndpointsFailedError, got %v", err)
+	}
+	if len(allFailed.Endpoints) != len(servers) || len(allFailed.Errors()) != len(servers) {
+		t.Fatalf("expected an error for each of the %d endpoints, got %v", len(servers), allFailed.Endpoints)
+	}
+	for i, server := range servers {
+		if hits[i] != 1 {
+			t.Errorf("expected one attempt on endpoint %s, got %d", server.URL, hits[i])
+		}
+		if !strings.Contains(err.Error(), server.URL+": ") {
+			t.Errorf("expected the error to list endpoint %s, got %v", server.URL, err)
+		}
+	}
+	var streamLoadErr *exception.StreamLoadError
+	if !errors.As(err, &streamLoadErr) {
+		t.Errorf("expected the error to unwrap to StreamLoadError")
+	}
+	if !errors.Is(err, allFailed.Endpoints[0]) {
+		t.Errorf("expected errors.Is to match an endpoint error")
+	}
+}
+
+func TestDNSFailureFailover(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(successResponse))
+	})
+	var lookups int32
+	cfg := newTestConfig(server)
+	cfg.Endpoints = []string{"http://fe1.invalid:8030", "http://fe2.invalid:8030", server.URL}
+	// Retries would wait longer than the test, the failover must not count as one
+	cfg.Retry = &config.Retry{MaxRetryTimes: 0, BaseIntervalMs: 60000, MaxTotalTimeMs: 600000}
+	cfg.HTTPClient = &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Hostname(), ".invalid") {
+			atomic.AddInt32(&lookups, 1)
+			return nil, &net.DNSError{Err: "no such host", Name: req.URL.Hostname(), IsNotFound: true}
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	// The endpoints are picked randomly, each load tries the unresolvable ones at most once
+	for i := 0; i < 10; i++ {
+		atomic.StoreInt32(&lookups, 0)
+		response, err := client.Load(strings.NewReader(`{"a":1}`))
+		if err != nil || response.Status != loader.SUCCESS {
+			t.Fatalf("expected the load to fail over to the healthy endpoint, got %v", err)
+		}
+		if got := atomic.LoadInt32(&lookups); got > 2 {
+			t.Errorf("expected at most one attempt per unresolvable endpoint, got %d", got)
+		}
+	}
+
+	// A load whose endpoints all fail to resolve stops once each was tried
+	cfg.Endpoints = cfg.Endpoints[:2]
+	client, err = NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	atomic.StoreInt32(&lookups, 0)
+	_, err = client.Load(strings.NewReader(`{"a":1}`))
+	var allFailed *exception.AllEndpointsFailedError
+	if !errors.As(err, &allFailed) {
+		t.Fatalf("expected AllEndpointsFailedError, got %v", err)
+	}
+	if got := atomic.LoadInt32(&lookups); got != 2 {
+		t.Errorf("expected one attempt per endpoint, got %d", got)
+	}
+}
+
+func TestConnectionPool(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(successResponse))
+	})
+
+	cfg := newTestConfig(server)
+	cfg.ConnectionPool = &config.ConnectionPool{MaxConnsPerHost: 2, MaxIdleConnsPerHost: 1}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+}
+
+func TestLoadWithLabelPrefix(t *testing.T) {
+	var labels []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		labels = append(labels, r.Header.Get("label"))
+		w.Write([]byte(successResponse))
+	})
+
+	cfg := newTestConfig(server)
+	cfg.LabelPrefix = "configured"
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.LoadWithLabelPrefix("pipeline_a", strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if len(labels) != 2 || !strings.HasPrefix(labels[0], "pipeline_a_test_db_test_table_") ||
+		!strings.HasPrefix(labels[1], "configured_test_db_test_table_") {
+		t.Fatalf("unexpected labels %v", labels)
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	var calls int32
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(successResponse))
+		w.Write(bytes.Repeat([]byte(" "), 4096))
+	})
+
+	cfg := newTestConfig(server)
+	cfg.MaxResponseBytes = 1024
+	cfg.Retry = &config.Retry{MaxRetryTimes: 2, BaseIntervalMs: 1, MaxTotalTimeMs: 1000}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.Load(strings.NewReader(`{"a":1}`))
+	if !errors.Is(err, loader.ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+	// The load may have been committed, so it is not retried
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 request, got %d", got)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	testCases := []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{name: "default", expected: "go-doris-sdk/" + config.Version},
+		{name: "override", userAgent: "my-app/2.3", expected: "my-app/2.3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("User-Agent")
+				w.Write([]byte(successResponse))
+			})
+
+			cfg := newTestConfig(server)
+			cfg.UserAgent = tc.userAgent
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected User-Agent %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestAuthorizationHeader(t *testing.T) {
+	var got []string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		w.Write([]byte(successResponse))
+	})
+
+	client, err := NewDorisClient(newTestConfig(server))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+	}
+	if err := client.UpdateCredentials("admin", "rotated"); err != nil {
+		t.Fatalf("failed to update credentials: %v", err)
+	}
+	if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	initial := "Basic " + base64.StdEncoding.EncodeToString([]byte("root:secret_password"))
+	rotated := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:rotated"))
+	expected := []string{initial, initial, rotated}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected Authorization headers %v, got %v", expected, got)
+	}
+}
+
+func BenchmarkAuthorizationHeader(b *testing.B) {
+	b.Run("encoded per request", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = loader.BasicAuthHeader("root", "secret_password")
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		auth := &authCache{}
+		auth.store("root", "secret_password")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = auth.get("root", "secret_password")
+		}
+	})
+}
+
+// brokenConnServer is a mock Doris that breaks the connection of the first stream load, before or after
+// reading the whole body, and answers load state queries with the given state
+type brokenConnServer struct {
+	readWholeBody bool
+	state         string
+	puts          int32
+	stateQueries  int32
+}
+
+func (s *brokenConnServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		atomic.AddInt32(&s.stateQueries, 1)
+		fmt.Fprintf(w, `{"msg":"success","code":0,"data":%q,"count":0}`, s.state)
+		return
+	}
+	if atomic.AddInt32(&s.puts, 1) > 1 {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(successResponse))
+		return
+	}
+	if s.readWholeBody {
+		io.Copy(io.Discard, r.Body)
+	} else {
+		io.CopyN(io.Discard, r.Body, 1024)
+	}
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		panic(err)
+	}
+	conn.Close()
+}
+
+func TestConnectionBrokenMidBody(t *testing.T) {
+	testCases := []struct {
+		name          string
+		readWholeBody bool
+		state         string
+		groupCommit   bool
+		expectSuccess bool
+		expectPuts    int32
+		expectQueries int32
+		expectUnknown bool
+	}{
+		{name: "write error is retried", expectSuccess: true, expectPuts: 2},
+		{name: "committed load is not sent again", readWholeBody: true, state: loader.LoadStateVisible,
+			expectSuccess: true, expectPuts: 1, expectQueries: 1},
+		{name: "unknown label is sent again", readWholeBody: true, state: loader.LoadStateUnknown,
+			expectSuccess: true, expectPuts: 2, expectQueries: 1},
+		{name: "running load is not sent again", readWholeBody: true, state: loader.LoadStatePrepare,
+			expectPuts: 1, expectQueries: 1, expectUnknown: true},
+		{name: "group commit without label is not sent again", readWholeBody: true, groupCommit: true,
+			expectPuts: 1, expectUnknown: true},
+	}
+
+	// Large enough not to fit in the socket buffers, so that the write fails before the body is sent
+	data := bytes.Repeat([]byte(`{"a":1}`+"\n"), 2<<20)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doris := &brokenConnServer{readWholeBody: tc.readWholeBody, state: tc.state}
+			server := httptest.NewServer(http.HandlerFunc(doris.handle))
+			t.Cleanup(server.Close)
+
+			cfg := newTestConfig(server)
+			cfg.Retry = &config.Retry{MaxRetryTimes: 1, BaseIntervalMs: 1, MaxTotalTimeMs: 1000}
+			if tc.groupCommit {
+				cfg.GroupCommit = config.ASYNC
+			}
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			response, err := client.Load(bytes.NewReader(data))
+			if tc.expectSuccess && (err != nil || response.Status != loader.SUCCESS) {
+				t.Fatalf("expected success, got %v", err)
+			}
+			var outcomeErr *exception.OutcomeUnknownError
+			if tc.expectUnknown != errors.As(err, &outcomeErr) {
+				t.Fatalf("expected OutcomeUnknownError: %t, got %v", tc.expectUnknown, err)
+			}
+			if got := atomic.LoadInt32(&doris.puts); got != tc.expectPuts {
+				t.Errorf("expected %d stream loads, got %d", tc.expectPuts, got)
+			}
+			if got := atomic.LoadInt32(&doris.stateQueries); got != tc.expectQueries {
+				t.Errorf("expected %d load state queries, got %d", tc.expectQueries, got)
+			}
+		})
+	}
+}
+
+func TestLabelAlreadyExistsWaitsForExistingJob(t *testing.T) {
+	pollInterval, maxWait := existingJobPollInterval, existingJobMaxWait
+	existingJobPollInterval, existingJobMaxWait = time.Millisecond, 50*time.Millisecond
+	t.Cleanup(func() { existingJobPollInterval, existingJobMaxWait = pollInterval, maxWait })
+
+	testCases := []struct {
+		existingJobStatus string
+		states            []string
+		expectSuccess     bool
+		expectLabelExists bool
+		expectUnknown     bool
+		expectQueries     int32
+	}{
+		{existingJobStatus: loader.ExistingJobFinished, expectSuccess: true},
+		{existingJobStatus: loader.ExistingJobVisible, expectSuccess: true},
+		{existingJobStatus: loader.ExistingJobCancelled, expectLabelExists: true},
+		{existingJobStatus: loader.ExistingJobRunning, states: []string{loader.LoadStatePrepare, loader.LoadStateVisible},
+			expectSuccess: true, expectQueries: 2},
+		{existingJobStatus: loader.ExistingJobRunning, states: []string{loader.LoadStateAborted},
+			expectLabelExists: true, expectQueries: 1},
+		{existingJobStatus: "PREPARE", states: []string{loader.LoadStateCommitted}, expectSuccess: true, expectQueries: 1},
+		{existingJobStatus: "PREPARE", states: []string{loader.LoadStatePrepare}, expectUnknown: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.existingJobStatus+"_"+strings.Join(tc.states, "_"), func(t *testing.T) {
+			var puts, stateQueries int32
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					i := int(atomic.AddInt32(&stateQueries, 1)) - 1
+					if i >= len(tc.states) {
+						i = len(tc.states) - 1
+					}
+					fmt.Fprintf(w, `{"msg":"success","code":0,"data":%q,"count":0}`, tc.states[i])
+					return
+				}
+				atomic.AddInt32(&puts, 1)
+				fmt.Fprintf(w, `{"Label":"l1","Status":"Label Already Exists","ExistingJobStatus":%q,"Message":"Label [l1] has already been used"}`,
+					tc.existingJobStatus)
+			})
+			cfg := newTestConfig(server)
+			cfg.Label = "l1"
+			cfg.Retry = &config.Retry{MaxRetryTimes: 2, BaseIntervalMs: 1, MaxTotalTimeMs: 1000}
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			response, err := client.Load(strings.NewReader(`{"a":1}`))
+			if tc.expectSuccess && (err != nil || response.Status != loader.SUCCESS) {
+				t.Fatalf("expected success, got %v", err)
+			}
+			var labelErr *exception.LabelExistsError
+			if tc.expectLabelExists != (errors.As(err, &labelErr) && !tc.expectUnknown) {
+				t.Errorf("expected LabelExistsError: %t, got %v", tc.expectLabelExists, err)
+			}
+			var outcomeErr *exception.OutcomeUnknownError
+			if tc.expectUnknown != errors.As(err, &outcomeErr) {
+				t.Errorf("expected OutcomeUnknownError: %t, got %v", tc.expectUnknown, err)
+			}
+			if got := atomic.LoadInt32(&puts); got != 1 {
+				t.Errorf("expected a single stream load, got %d", got)
+			}
+			if got := atomic.LoadInt32(&stateQueries); tc.expectQueries > 0 && got != tc.expectQueries {
+				t.Errorf("expected %d load state queries, got %d", tc.expectQueries, got)
+			}
+		})
+	}
+}
+
+func TestValidateConnection(t *testing.T) {
+	schemaServer := func(status int, body string) *httptest.Server {
+		return newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || r.URL.Path != "/api/test_db/test_table/_schema" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		})
+	}
+	healthy := schemaServer(http.StatusOK, `{"msg":"success","code":0,"data":{"properties":[]}}`)
+	unauthorized := schemaServer(http.StatusUnauthorized, "")
+	missingTable := schemaServer(http.StatusOK, `{"msg":"Table [test_table] does not exist","code":1}`)
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	t.Run("all healthy", func(t *testing.T) {
+		cfg := newTestConfig(healthy)
+		client, err := NewDorisClient(cfg)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		if err := client.ValidateConnection(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		cfg := newTestConfig(healthy)
+		cfg.Endpoints = append(cfg.Endpoints, unauthorized.URL, missingTable.URL, unreachable.URL)
+		client, err := NewDorisClient(cfg)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		err = client.ValidateConnection(context.Background())
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		message := err.Error()
+		if strings.Contains(message, healthy.URL+" ") {
+			t.Errorf("healthy endpoint should not be reported: %s", message)
+		}
+		for _, endpoint := range []string{unauthorized.URL, missingTable.URL, unreachable.URL} {
+			if !strings.Contains(message, "endpoint "+endpoint+" (test_db.test_table)") {
+				t.Errorf("expected %s to be reported: %s", endpoint, message)
+			}
+		}
+		var authErr *exception.AuthError
+		if !errors.As(err, &authErr) {
+			t.Errorf("expected an AuthError among the problems: %v", err)
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		client, err := NewDorisClient(newTestConfig(healthy))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := client.ValidateConnection(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestDescribeTable(t *testing.T) {
+	schema := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/test_db/test_table/_schema" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"msg":"success","code":0,"data":{"keysType":"DUP_KEYS","properties":[` +
+			`{"name":"id","aggregation_type":"","type":"BIGINT","comment":"","is_nullable":"No"},` +
+			`{"name":"message","aggregation_type":"","type":"VARCHAR","comment":"","is_nullable":"Yes"}]}}`))
+	})
+	missingTable := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"msg":"Table [test_table] does not exist","code":1}`))
+	})
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	want := []loader.ColumnInfo{
+		{Name: "id", Type: "BIGINT"},
+		{Name: "message", Type: "VARCHAR", Nullable: true},
+	}
+
+	testCases := []struct {
+		name      string
+		endpoints []string
+		wantErr   bool
+	}{
+		{name: "healthy", endpoints: []string{schema.URL}},
+		{name: "fails over to the next endpoint", endpoints: []string{unreachable.URL, schema.URL}},
+		{name: "missing table", endpoints: []string{missingTable.URL}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(schema)
+			cfg.Endpoints = tc.endpoints
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			columns, err := client.DescribeTable(context.Background())
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "does not exist") {
+					t.Fatalf("expected the schema error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(columns, want) {
+				t.Errorf("expected columns %+v, got %+v", want, columns)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	log "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/logger"
+)
+
+// endpointRefresher holds the latest endpoints of the EndpointProvider, shared by all loads of a client
+type endpointRefresher struct {
+	mu          sync.Mutex
+	endpoints   []string
+	refreshedAt time.Time
+	now         func() time.Time
+}
+
+// newEndpointRefresher creates a refresher that has not fetched any endpoints yet
+func newEndpointRefresher() *endpointRefresher {
+	return &endpointRefresher{now: time.Now}
+}
+
+// fetchEndpoints calls the provider and returns its endpoints if they are valid, without embedded credentials
+func fetchEndpoints(provider func() ([]string, error)) ([]string, error) {
+	endpoints, err := provider()
+	if err != nil {
+		return nil, fmt.Errorf("endpoint provider failed: %w", err)
+	}
+	if err := config.ValidateEndpoints(endpoints); err != nil {
+		return nil, fmt.Errorf("endpoint provider returned %w", err)
+	}
+	return stripCredentials(endpoints), nil
+}
+
+// store records endpoints fetched when the client was created
+func (r *endpointRefresher) store(endpoints []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints = endpoints
+	r.refreshedAt = r.now()
+}
+
+// current returns the latest endpoints of the provider of cfg, refreshing them once the refresh interval has passed
+// The endpoints of cfg are returned as long as the provider never succeeded
+func (r *endpointRefresher) current(cfg *config.Config) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.endpoints != nil && r.now().Sub(r.refreshedAt) < cfg.GetEndpointRefreshInterval() {
+		return r.endpoints
+	}
+	// A failing provider is called again after the interval, not on every load
+	r.refreshedAt = r.now()
+	endpoints, err := fetchEndpoints(cfg.EndpointProvider)
+	if err != nil {
+		log.Warnf("Failed to refresh the endpoints, keeping the previous ones: %v", err)
+		if r.endpoints == nil {
+			r.endpoints = cfg.Endpoints
+		}
+		return r.endpoints
+	}
+	if !equalEndpoints(r.endpoints, endpoints) {
+		log.Infof("Endpoints refreshed: %v", endpoints)
+	}
+	r.endpoints = endpoints
+	return endpoints
+}
+
+// equalEndpoints reports whether two endpoint lists are the same
+func equalEndpoints(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// endpointProvider is a provider whose endpoints and error are changed by the tests
+type endpointProvider struct {
+	mu        sync.Mutex
+	endpoints []string
+	err       error
+	calls     int
+}
+
+func (p *endpointProvider) set(endpoints []string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.endpoints = endpoints
+	p.err = err
+}
+
+func (p *endpointProvider) provide() ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	return p.endpoints, p.err
+}
+
+func TestEndpointProviderRefresh(t *testing.T) {
+	var mu sync.Mutex
+	var hits []string
+	newServer := func(name string) *httptest.Server {
+		return newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			hits = append(hits, name)
+			mu.Unlock()
+			w.Write([]byte(successResponse))
+		})
+	}
+	first, second := newServer("first"), newServer("second")
+
+	provider := &endpointProvider{endpoints: []string{first.URL}}
+	cfg := newTestConfig(first)
+	cfg.Endpoints = nil
+	cfg.EndpointProvider = provider.provide
+	cfg.EndpointRefreshInterval = time.Minute
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	now := time.Now()
+	client.endpoints.now = func() time.Time { return now }
+
+	load := func() {
+		t.Helper()
+		if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+	}
+
+	// The new endpoints are only used once the refresh interval has passed
+	load()
+	provider.set([]string{second.URL}, nil)
+	load()
+	now = now.Add(time.Minute)
+	load()
+	// A failing or invalid provider keeps the previous endpoints
+	provider.set(nil, errors.New("discovery unavailable"))
+	now = now.Add(time.Minute)
+	load()
+	provider.set([]string{"not-a-url"}, nil)
+	now = now.Add(time.Minute)
+	load()
+
+	expected := []string{"first", "first", "second", "second", "second"}
+	if strings.Join(hits, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected loads to %v, got %v", expected, hits)
+	}
+	if provider.calls != 4 {
+		t.Errorf("expected the provider to be called on creation and 3 refreshes, got %d calls", provider.calls)
+	}
+}
+
+func TestEndpointProviderInitialFailure(t *testing.T) {
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(successResponse))
+	})
+	provider := &endpointProvider{err: errors.New("discovery unavailable")}
+
+	testCases := []struct {
+		name      string
+		endpoints []string
+		wantErr   bool
+	}{
+		{name: "without configured endpoints", endpoints: nil, wantErr: true},
+		{name: "with configured endpoints", endpoints: []string{server.URL}, wantErr: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig(server)
+			cfg.Endpoints = tc.endpoints
+			cfg.EndpointProvider = provider.provide
+			client, err := NewDorisClient(cfg)
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "discovery unavailable") {
+					t.Fatalf("expected the provider error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			if _, err := client.Load(strings.NewReader(`{"a":1}`)); err != nil {
+				t.Fatalf("load to the configured endpoints failed: %v", err)
+			}
+		})
+	}
+}
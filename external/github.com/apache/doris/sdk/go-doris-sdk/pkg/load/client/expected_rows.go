@@ -0,0 +1,60 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+	log "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/logger"
+)
+
+// rowCountTolerancePercent is how far the rows counted by Doris may be from the expected ones without a warning
+const rowCountTolerancePercent = 1
+
+// expectedRowsKey is the context key of the number of rows the caller knows a load holds
+type expectedRowsKey struct{}
+
+// WithExpectedRows returns a context telling the loads run with it how many rows the data holds, so that a
+// successful load whose NumberTotalRows differs by more than 1% logs a warning, e.g. when some records were
+// split or merged by the line delimiter. Zero or less expects nothing
+func WithExpectedRows(ctx context.Context, rows int64) context.Context {
+	return context.WithValue(ctx, expectedRowsKey{}, rows)
+}
+
+// expectedRows returns the number of rows set with WithExpectedRows, zero if none
+func expectedRows(ctx context.Context) int64 {
+	rows, _ := ctx.Value(expectedRowsKey{}).(int64)
+	return rows
+}
+
+// warnIfRowCountDiffers warns when Doris counted materially more or fewer rows than the caller expected
+func warnIfRowCountDiffers(ctx context.Context, logger *log.ContextLogger, response *loader.LoadResponse) {
+	expected := expectedRows(ctx)
+	if expected <= 0 {
+		return
+	}
+	diff := response.Resp.NumberTotalRows - expected
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff*100 <= expected*rowCountTolerancePercent {
+		return
+	}
+	logger.Warnf("Doris counted %d rows while %d were expected, label: %s", response.Resp.NumberTotalRows, expected, response.Label)
+}
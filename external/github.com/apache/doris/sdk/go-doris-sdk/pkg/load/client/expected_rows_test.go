@@ -0,0 +1,68 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExpectedRowsMismatch(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expected    int64
+		totalRows   int64
+		expectWarn  bool
+		withContext bool
+	}{
+		{name: "matching count", expected: 1000, totalRows: 1000, withContext: true},
+		{name: "difference within tolerance", expected: 1000, totalRows: 1005, withContext: true},
+		{name: "more rows than expected", expected: 1000, totalRows: 1200, expectWarn: true, withContext: true},
+		{name: "fewer rows than expected", expected: 10, totalRows: 9, expectWarn: true, withContext: true},
+		{name: "zero expects nothing", expected: 0, totalRows: 5, withContext: true},
+		{name: "no expected rows", totalRows: 5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"TxnId":1,"Label":"test","Status":"Success","NumberTotalRows":%d,"NumberLoadedRows":%d}`,
+					tc.totalRows, tc.totalRows)
+			})
+			client, err := NewDorisClient(newTestConfig(server))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			logs := captureLogs(t)
+
+			ctx := context.Background()
+			if tc.withContext {
+				ctx = WithExpectedRows(ctx, tc.expected)
+			}
+			if _, err := client.LoadContext(ctx, strings.NewReader(`{"a":1}`)); err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+			if warned := strings.Contains(logs.String(), "were expected"); warned != tc.expectWarn {
+				t.Errorf("expected warning %t, got logs:\n%s", tc.expectWarn, logs.String())
+			}
+		})
+	}
+}
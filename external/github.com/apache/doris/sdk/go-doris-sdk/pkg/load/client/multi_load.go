@@ -0,0 +1,140 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+)
+
+// ReaderJoin describes how LoadMulti joins several readers into the body of one load
+type ReaderJoin struct {
+	Prefix    string // Written before the first reader
+	Separator string // Written between two non-empty readers
+	Suffix    string // Written after the last reader
+	// Terminator is appended to every non-empty reader not already ending with it, e.g. a line delimiter
+	Terminator string
+}
+
+// LoadMulti loads the data of several readers as a single load, joined according to the format:
+// with JSON object lines and CSV each reader holds whole lines and is terminated by the line delimiter if needed,
+// with JSON arrays each reader holds one JSON value and the values are joined into one array
+// Empty readers are skipped. The joined data is read once, so it is buffered up to MaxBufferBytes for retries and streamed beyond
+func (c *DorisLoadClient) LoadMulti(readers ...io.Reader) (*loader.LoadResponse, error) {
+	return c.LoadMultiWithJoin(defaultReaderJoin(c.currentConfig().Format), readers...)
+}
+
+// LoadMultiWithJoin loads the data of several readers as a single load, joined as described by join
+func (c *DorisLoadClient) LoadMultiWithJoin(join ReaderJoin, readers ...io.Reader) (*loader.LoadResponse, error) {
+	return c.Load(&joinedReader{join: join, readers: readers})
+}
+
+// defaultReaderJoin returns how the readers of the format are joined
+func defaultReaderJoin(format config.Format) ReaderJoin {
+	switch f := format.(type) {
+	case *config.JSONFormat:
+		if f.Type == config.JSONArray {
+			return ReaderJoin{Prefix: "[", Separator: ",", Suffix: "]"}
+		}
+	case *config.CSVFormat:
+		return ReaderJoin{Terminator: f.LineDelimiter}
+	}
+	return ReaderJoin{Terminator: "\n"}
+}
+
+// joinedReader reads the readers one after the other, adding the prefix, separators, terminators and suffix
+type joinedReader struct {
+	join    ReaderJoin
+	readers []io.Reader
+
+	started bool
+	done    bool
+	index   int
+	read    bool   // Whether the current reader returned any data
+	wrote   bool   // Whether any reader returned data
+	tail    []byte // Last bytes of the current reader, up to the length of the terminator
+	pending []byte // Bytes to return before reading further
+}
+
+// Read implements io.Reader
+func (r *joinedReader) Read(p []byte) (int, error) {
+	for {
+		if len(r.pending) > 0 {
+			n := copy(p, r.pending)
+			r.pending = r.pending[n:]
+			return n, nil
+		}
+		if !r.started {
+			r.started = true
+			r.pending = []byte(r.join.Prefix)
+			continue
+		}
+		if r.index >= len(r.readers) {
+			if r.done {
+				return 0, io.EOF
+			}
+			r.done = true
+			r.pending = []byte(r.join.Suffix)
+			continue
+		}
+
+		n, err := r.readers[r.index].Read(p)
+		if n > 0 {
+			r.keepTail(p[:n])
+			if !r.read && r.wrote && r.join.Separator != "" {
+				// The separator goes before the data just read, which waits behind it
+				r.pending = append(append(r.pending, r.join.Separator...), p[:n]...)
+				n = 0
+			}
+			r.read, r.wrote = true, true
+		}
+		if errors.Is(err, io.EOF) {
+			r.finishReader()
+			err = nil
+		}
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+}
+
+// keepTail remembers the last bytes read from the current reader to check the terminator
+func (r *joinedReader) keepTail(data []byte) {
+	size := len(r.join.Terminator)
+	if size == 0 {
+		return
+	}
+	r.tail = append(r.tail, data...)
+	if len(r.tail) > size {
+		r.tail = append(r.tail[:0], r.tail[len(r.tail)-size:]...)
+	}
+}
+
+// finishReader queues the terminator after the current reader and moves to the next one
+func (r *joinedReader) finishReader() {
+	if r.read && !bytes.HasSuffix(r.tail, []byte(r.join.Terminator)) {
+		r.pending = append(r.pending, r.join.Terminator...)
+	}
+	r.index++
+	r.read = false
+	r.tail = r.tail[:0]
+}
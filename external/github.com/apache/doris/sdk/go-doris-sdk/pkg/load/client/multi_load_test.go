@@ -0,0 +1,128 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+)
+
+func TestJoinedReader(t *testing.T) {
+	inputs := []string{`{"a":1}`, "{\"a\":2}\n", "", `{"a":3}`}
+	testCases := []struct {
+		name     string
+		join     ReaderJoin
+		expected string
+	}{
+		{
+			name:     "json object lines",
+			join:     defaultReaderJoin(&config.JSONFormat{Type: config.JSONObjectLine}),
+			expected: "{\"a\":1}\n{\"a\":2}\n{\"a\":3}\n",
+		},
+		{
+			name:     "json array",
+			join:     defaultReaderJoin(&config.JSONFormat{Type: config.JSONArray}),
+			expected: "[{\"a\":1},{\"a\":2}\n,{\"a\":3}]",
+		},
+		{
+			name:     "multi-byte terminator",
+			join:     defaultReaderJoin(&config.CSVFormat{ColumnSeparator: ",", LineDelimiter: "\r\n"}),
+			expected: "{\"a\":1}\r\n{\"a\":2}\n\r\n{\"a\":3}\r\n",
+		},
+		{
+			name:     "override",
+			join:     ReaderJoin{Separator: "\x1e"},
+			expected: "{\"a\":1}\x1e{\"a\":2}\n\x1e{\"a\":3}",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			readers := make([]io.Reader, len(inputs))
+			for i, input := range inputs {
+				readers[i] = strings.NewReader(input)
+			}
+			data, err := io.ReadAll(&joinedReader{join: tc.join, readers: readers})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, data)
+			}
+		})
+	}
+}
+
+func TestLoadMulti(t *testing.T) {
+	var body string
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(successResponse))
+	})
+
+	inputs := []string{`{"id":1}`, "{\"id\":2}\n", `{"id":3}`}
+	newReaders := func() []io.Reader {
+		readers := make([]io.Reader, len(inputs))
+		for i, input := range inputs {
+			readers[i] = strings.NewReader(input)
+		}
+		return readers
+	}
+
+	// The same readers form a valid JSON array body
+	cfg := newTestConfig(server)
+	cfg.Format = &config.JSONFormat{Type: config.JSONArray}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.LoadMulti(newReaders()...); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	var records []map[string]int
+	if err := json.Unmarshal([]byte(body), &records); err != nil || len(records) != 3 || records[2]["id"] != 3 {
+		t.Errorf("expected a valid array of 3 records, got %q (%v)", body, err)
+	}
+
+	// And a valid JSON object line body
+	cfg = newTestConfig(server)
+	cfg.Format = &config.JSONFormat{Type: config.JSONObjectLine}
+	client, err = NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.LoadMulti(newReaders()...); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", body)
+	}
+	for _, line := range lines {
+		var record map[string]int
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Errorf("invalid line %q: %v", line, err)
+		}
+	}
+}
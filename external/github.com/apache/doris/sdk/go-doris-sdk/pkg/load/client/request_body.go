@@ -0,0 +1,180 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+)
+
+// errBodyConsumed is returned when a streamed body is requested again after it was sent
+var errBodyConsumed = errors.New("streamed request body was already sent and cannot be replayed")
+
+// bodyMode is how the data of a load is sent
+type bodyMode int
+
+const (
+	// bodyBuffered holds the data in memory, requests have a Content-Length and can always be retried
+	bodyBuffered bodyMode = iota
+	// bodySeeked rewinds a seekable reader for each request, requests are sent chunked
+	bodySeeked
+	// bodyStreamed sends a non-seekable reader chunked, it cannot be retried once read
+	bodyStreamed
+	// bodyRanged reads an io.ReaderAt from offset 0 for each request, requests have a Content-Length
+	bodyRanged
+)
+
+func (m bodyMode) String() string {
+	switch m {
+	case bodyBuffered:
+		return "buffered"
+	case bodySeeked:
+		return "seeked"
+	case bodyStreamed:
+		return "streamed"
+	case bodyRanged:
+		return "ranged"
+	default:
+		return "unknown"
+	}
+}
+
+// requestBody provides a fresh reader of the load data for each request
+type requestBody struct {
+	mode bodyMode
+	size int64 // Size of the data, -1 if unknown until it is streamed
+	get  func() (io.Reader, error)
+	data []byte // Data of a buffered body
+}
+
+// isEmpty reports whether the body holds no data: nothing but whitespace, or an empty array with the JSON array format
+func (b *requestBody) isEmpty(format config.Format) bool {
+	if b.size == 0 {
+		return true
+	}
+	if b.mode != bodyBuffered {
+		return false
+	}
+	data := bytes.TrimSpace(b.data)
+	if jsonFormat, ok := format.(*config.JSONFormat); ok && jsonFormat.Type == config.JSONArray &&
+		bytes.HasPrefix(data, []byte{'['}) && bytes.HasSuffix(data, []byte{']'}) {
+		data = bytes.TrimSpace(data[1 : len(data)-1])
+	}
+	return len(data) == 0
+}
+
+// newRequestBody decides how the data is sent based on its size
+// Data up to maxBufferBytes is held in memory, larger seekable readers are rewound for each request,
+// and larger non-seekable readers are streamed without holding them in memory
+func newRequestBody(reader io.Reader, maxBufferBytes int64) (*requestBody, error) {
+	if seeker, ok := reader.(io.Seeker); ok {
+		size, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure reader size: %w", err)
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("failed to seek to start: %w", err)
+		}
+		if size <= maxBufferBytes {
+			return bufferBody(reader)
+		}
+		return &requestBody{
+			mode: bodySeeked,
+			size: size,
+			get: func() (io.Reader, error) {
+				if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+					return nil, fmt.Errorf("failed to seek to start: %w", err)
+				}
+				return reader, nil
+			},
+		}, nil
+	}
+
+	// Read one byte past the threshold to tell whether the data fits
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(io.LimitReader(reader, maxBufferBytes+1)); err != nil {
+		return nil, fmt.Errorf("failed to buffer reader content: %w", err)
+	}
+	if int64(buf.Len()) <= maxBufferBytes {
+		return bufferedBody(buf.Bytes()), nil
+	}
+
+	stream := &onceReader{reader: io.MultiReader(&buf, reader)}
+	return &requestBody{
+		mode: bodyStreamed,
+		size: -1,
+		get: func() (io.Reader, error) {
+			// A request rejected before the body was sent, e.g. redirected by FE, can still use it
+			if stream.read {
+				return nil, errBodyConsumed
+			}
+			return stream, nil
+		},
+	}, nil
+}
+
+// rangedBody returns a body reading the first size bytes of r without holding them in memory
+func rangedBody(r io.ReaderAt, size int64) *requestBody {
+	return &requestBody{
+		mode: bodyRanged,
+		size: size,
+		get: func() (io.Reader, error) {
+			return io.NewSectionReader(r, 0, size), nil
+		},
+	}
+}
+
+// bufferBody reads the whole reader into memory
+func bufferBody(reader io.Reader) (*requestBody, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer reader content: %w", err)
+	}
+	return bufferedBody(data), nil
+}
+
+// bufferedBody returns a body serving copies of a reader over the data
+func bufferedBody(data []byte) *requestBody {
+	return &requestBody{
+		mode: bodyBuffered,
+		size: int64(len(data)),
+		get: func() (io.Reader, error) {
+			return bytes.NewReader(data), nil
+		},
+		data: data,
+	}
+}
+
+// onceReader records whether any data was read from the underlying reader
+type onceReader struct {
+	reader io.Reader
+	read   bool
+}
+
+// Read implements io.Reader
+func (r *onceReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.read = true
+	}
+	return n, err
+}
@@ -0,0 +1,224 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+)
+
+// nonSeekableReader hides the io.Seeker implementation of the wrapped reader
+type nonSeekableReader struct {
+	io.Reader
+}
+
+func TestNewRequestBodyThreshold(t *testing.T) {
+	const limit = 16
+
+	testCases := []struct {
+		name     string
+		size     int
+		seekable bool
+		expected bodyMode
+	}{
+		{name: "seekable below limit", size: limit - 1, seekable: true, expected: bodyBuffered},
+		{name: "seekable at limit", size: limit, seekable: true, expected: bodyBuffered},
+		{name: "seekable above limit", size: limit + 1, seekable: true, expected: bodySeeked},
+		{name: "non-seekable below limit", size: limit - 1, expected: bodyBuffered},
+		{name: "non-seekable at limit", size: limit, expected: bodyBuffered},
+		{name: "non-seekable above limit", size: limit + 1, expected: bodyStreamed},
+		{name: "empty", size: 0, expected: bodyBuffered},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := strings.Repeat("x", tc.size)
+			var reader io.Reader = strings.NewReader(data)
+			if !tc.seekable {
+				reader = nonSeekableReader{reader}
+			}
+
+			body, err := newRequestBody(reader, limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if body.mode != tc.expected {
+				t.Fatalf("expected %s body, got %s", tc.expected, body.mode)
+			}
+
+			content, err := body.get()
+			if err != nil {
+				t.Fatalf("failed to get body: %v", err)
+			}
+			got, _ := io.ReadAll(content)
+			if string(got) != data {
+				t.Fatalf("expected %d bytes of data, got %d", len(data), len(got))
+			}
+
+			// Every mode except streaming can be sent again
+			_, err = body.get()
+			if tc.expected == bodyStreamed {
+				if !errors.Is(err, errBodyConsumed) {
+					t.Fatalf("expected errBodyConsumed, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("expected body to be replayable, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStreamedBodyReusableBeforeRead(t *testing.T) {
+	body, err := newRequestBody(nonSeekableReader{strings.NewReader("0123456789")}, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := body.get(); err != nil {
+		t.Fatalf("failed to get body: %v", err)
+	}
+	// The first request was redirected before sending anything
+	content, err := body.get()
+	if err != nil {
+		t.Fatalf("expected unread streamed body to be reusable, got %v", err)
+	}
+	got, _ := io.ReadAll(content)
+	if string(got) != "0123456789" {
+		t.Fatalf("unexpected data %q", got)
+	}
+}
+
+func TestLoadMaxBufferBytes(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"a":1}`+"\n"), 8)
+
+	testCases := []struct {
+		name          string
+		maxBuffer     int64
+		expectChunked bool
+		expectedCalls int32
+	}{
+		{name: "buffered and retried", maxBuffer: int64(len(data)), expectChunked: false, expectedCalls: 2},
+		{name: "streamed without retry", maxBuffer: int64(len(data)) - 1, expectChunked: true, expectedCalls: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				if chunked := r.ContentLength == -1; chunked != tc.expectChunked {
+					t.Errorf("expected chunked: %t, got content length %d", tc.expectChunked, r.ContentLength)
+				}
+				w.Write([]byte(`{"Status":"Fail","Message":"service unavailable"}`))
+			})
+
+			cfg := newTestConfig(server)
+			cfg.MaxBufferBytes = tc.maxBuffer
+			cfg.Retry = &config.Retry{MaxRetryTimes: 1, BaseIntervalMs: 1, MaxTotalTimeMs: 1000}
+			client, err := NewDorisClient(cfg)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if _, err := client.Load(nonSeekableReader{bytes.NewReader(data)}); err == nil {
+				t.Fatal("expected load to fail")
+			}
+			if got := atomic.LoadInt32(&calls); got != tc.expectedCalls {
+				t.Fatalf("expected %d requests, got %d", tc.expectedCalls, got)
+			}
+		})
+	}
+}
+
+// countingReaderAt counts the reads starting at offset 0
+type countingReaderAt struct {
+	data  []byte
+	reads int32
+}
+
+// ReadAt implements io.ReaderAt
+func (r *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off == 0 {
+		atomic.AddInt32(&r.reads, 1)
+	}
+	return bytes.NewReader(r.data).ReadAt(p, off)
+}
+
+func TestLoadFromReaderAt(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"a":1}`+"\n"), 8)
+	source := &countingReaderAt{data: append(data, "trailing bytes past size"...)}
+
+	var calls int32
+	server := newMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.ContentLength != int64(len(data)) {
+			t.Errorf("expected content length %d, got %d", len(data), r.ContentLength)
+		}
+		if !bytes.Equal(body, data) {
+			t.Errorf("unexpected body %q", body)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Write([]byte(`{"Status":"Fail","Message":"service unavailable"}`))
+			return
+		}
+		w.Write([]byte(successResponse))
+	})
+
+	cfg := newTestConfig(server)
+	// The data is not buffered even when it fits the buffer limit
+	cfg.MaxBufferBytes = int64(len(data)) * 2
+	cfg.Retry = &config.Retry{MaxRetryTimes: 1, BaseIntervalMs: 1, MaxTotalTimeMs: 1000}
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	response, err := client.LoadFromReaderAt(source, int64(len(data)))
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if response.Status != loader.SUCCESS {
+		t.Fatalf("expected success, got %v", response.Status)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 requests, got %d", got)
+	}
+	if got := atomic.LoadInt32(&source.reads); got != 2 {
+		t.Fatalf("expected the data to be read from offset 0 for each attempt, got %d reads", got)
+	}
+}
+
+func TestLoadFromReaderAtInvalid(t *testing.T) {
+	client, err := NewDorisClient(newTestConfig(newMockServer(t, func(w http.ResponseWriter, r *http.Request) {})))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.LoadFromReaderAt(nil, 1); err == nil {
+		t.Fatal("expected error for nil reader")
+	}
+	if _, err := client.LoadFromReaderAt(strings.NewReader("x"), -1); err == nil {
+		t.Fatal("expected error for negative size")
+	}
+}
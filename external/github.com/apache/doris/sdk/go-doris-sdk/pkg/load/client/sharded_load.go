@@ -0,0 +1,220 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+	log "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/logger"
+)
+
+// LoadSharded splits the data at record boundaries into at most the given number of shards,
+// loads the shards to different endpoints in parallel, and aggregates the results
+// The whole data is read in memory to be split. It fails if any shard fails, in which case
+// the rows of the other shards may have been loaded already.
+func (c *DorisLoadClient) LoadSharded(reader io.Reader, shards int) (*loader.LoadResponse, error) {
+	if shards <= 0 {
+		return nil, fmt.Errorf("shards must be positive, got %d", shards)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data: %w", err)
+	}
+
+	start := time.Now()
+	cfg := c.currentConfig()
+	parts, err := splitRecords(data, cfg.Format, shards)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split data: %w", err)
+	}
+	if len(parts) <= 1 {
+		return c.Load(bytes.NewReader(data))
+	}
+
+	log.Infof("Loading %d bytes in %d shards to %d endpoints", len(data), len(parts), len(cfg.Endpoints))
+
+	responses := make([]*loader.LoadResponse, len(parts))
+	errs := make([]error, len(parts))
+	var wg sync.WaitGroup
+	for i, part := range parts {
+		wg.Add(1)
+		go func(i int, part []byte) {
+			defer wg.Done()
+			responses[i], errs[i] = c.shardClient(cfg, i).Load(bytes.NewReader(part))
+		}(i, part)
+	}
+	wg.Wait()
+
+	aggregate, err := aggregateShardResponses(responses, errs)
+	aggregate.Duration = time.Since(start)
+	return aggregate, err
+}
+
+// shardClient creates a client sending the shard with the given index to a single endpoint, chosen round-robin
+func (c *DorisLoadClient) shardClient(base *config.Config, index int) *DorisLoadClient {
+	cfg := *base
+	cfg.Endpoints = []string{base.Endpoints[index%len(base.Endpoints)]}
+	if cfg.Label != "" {
+		// Each shard is a separate load job and needs its own label
+		cfg.Label = fmt.Sprintf("%s_shard_%d", cfg.Label, index)
+	}
+	return c.withConfig(&cfg)
+}
+
+// aggregateShardResponses merges the responses of all shards, failing if any shard failed
+func aggregateShardResponses(responses []*loader.LoadResponse, errs []error) (*loader.LoadResponse, error) {
+	aggregate := &loader.LoadResponse{Status: loader.SUCCESS}
+	aggregate.Resp.Status = loader.StatusSuccess
+	var labels, failures []string
+	var firstErr error
+
+	var attemptLabels []string
+	for i, response := range responses {
+		if response != nil {
+			aggregate.Attempts += response.Attempts
+			if response.Label != "" {
+				attemptLabels = append(attemptLabels, response.Label)
+			}
+		}
+		if errs[i] != nil || response == nil || response.Status != loader.SUCCESS {
+			err := errs[i]
+			if err == nil {
+				err = fmt.Errorf("load failed")
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			failures = append(failures, fmt.Sprintf("shard %d: %v", i, err))
+			continue
+		}
+
+		resp := response.Resp
+		addRowCounts(&aggregate.Resp, &resp)
+		// Shards run in parallel, so the slowest one is the load time
+		if resp.LoadTimeMs > aggregate.Resp.LoadTimeMs {
+			aggregate.Resp.LoadTimeMs = resp.LoadTimeMs
+		}
+		if resp.Label != "" {
+			labels = append(labels, resp.Label)
+		}
+	}
+	aggregate.Resp.Label = strings.Join(labels, ",")
+	aggregate.Label = strings.Join(attemptLabels, ",")
+
+	if len(failures) > 0 {
+		aggregate.Status = loader.FAILURE
+		aggregate.Resp.Status = loader.StatusFail
+		aggregate.ErrorMessage = strings.Join(failures, "; ")
+		log.Errorf("Sharded load failed: %s", aggregate.ErrorMessage)
+		return aggregate, fmt.Errorf("%d of %d shards failed: %s: %w", len(failures), len(responses), aggregate.ErrorMessage, firstErr)
+	}
+
+	log.Infof("Sharded load completed, %d shards, %d rows loaded", len(responses), aggregate.Resp.NumberLoadedRows)
+	return aggregate, nil
+}
+
+// addRowCounts adds the row and byte counts of a response to the aggregate
+func addRowCounts(aggregate, resp *loader.RespContent) {
+	aggregate.NumberTotalRows += resp.NumberTotalRows
+	aggregate.NumberLoadedRows += resp.NumberLoadedRows
+	aggregate.NumberFilteredRows += resp.NumberFilteredRows
+	aggregate.NumberUnselectedRows += resp.NumberUnselectedRows
+	aggregate.LoadBytes += resp.LoadBytes
+}
+
+// splitRecords splits the data at record boundaries of the format into at most the given number of parts
+func splitRecords(data []byte, format config.Format, shards int) ([][]byte, error) {
+	if jsonFormat, ok := format.(*config.JSONFormat); ok && jsonFormat.Type == config.JSONArray {
+		return splitJSONArray(data, shards)
+	}
+
+	delimiter := []byte("\n")
+	if csvFormat, ok := format.(*config.CSVFormat); ok {
+		delimiter = []byte(csvFormat.LineDelimiter)
+	}
+
+	records := bytes.Split(data, delimiter)
+	if len(records) > 0 && len(records[len(records)-1]) == 0 {
+		records = records[:len(records)-1]
+	}
+
+	groups := groupRecords(records, shards)
+	parts := make([][]byte, 0, len(groups))
+	for _, group := range groups {
+		var part bytes.Buffer
+		for _, record := range group {
+			part.Write(record)
+			part.Write(delimiter)
+		}
+		parts = append(parts, part.Bytes())
+	}
+	return parts, nil
+}
+
+// splitJSONArray splits a JSON array into at most the given number of JSON arrays
+func splitJSONArray(data []byte, shards int) ([][]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("data is not a JSON array")
+	}
+
+	var records [][]byte
+	for decoder.More() {
+		var record json.RawMessage
+		if err := decoder.Decode(&record); err != nil {
+			return nil, fmt.Errorf("invalid JSON array element: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	groups := groupRecords(records, shards)
+	parts := make([][]byte, 0, len(groups))
+	for _, group := range groups {
+		part := append([]byte{'['}, bytes.Join(group, []byte{','})...)
+		parts = append(parts, append(part, ']'))
+	}
+	return parts, nil
+}
+
+// groupRecords distributes the records into at most the given number of contiguous groups of similar size
+func groupRecords(records [][]byte, shards int) [][][]byte {
+	if shards > len(records) {
+		shards = len(records)
+	}
+	groups := make([][][]byte, 0, shards)
+	start := 0
+	for i := 0; i < shards; i++ {
+		// Spread the remainder over the first groups
+		end := start + len(records)/shards
+		if i < len(records)%shards {
+			end++
+		}
+		groups = append(groups, records[start:end])
+		start = end
+	}
+	return groups
+}
@@ -0,0 +1,164 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+)
+
+// shardServer is a mock endpoint counting the requests and rows it received
+type shardServer struct {
+	mu       sync.Mutex
+	requests int
+	rows     []int
+	fail     bool
+}
+
+func (s *shardServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rows := strings.Count(string(body), "\n")
+
+	s.mu.Lock()
+	s.requests++
+	s.rows = append(s.rows, rows)
+	s.mu.Unlock()
+
+	status := "Success"
+	if s.fail {
+		status = "Fail"
+	}
+	fmt.Fprintf(w, `{"Status":%q,"Label":%q,"NumberTotalRows":%d,"NumberLoadedRows":%d,"LoadBytes":%d,"LoadTimeMs":%d}`,
+		status, r.Header.Get("label"), rows, rows, len(body), rows)
+}
+
+func TestLoadSharded(t *testing.T) {
+	first, second := &shardServer{}, &shardServer{}
+	cfg := newTestConfig(newMockServer(t, first.handle))
+	cfg.Endpoints = append(cfg.Endpoints, newMockServer(t, second.handle).URL)
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var data strings.Builder
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(&data, "{\"id\":%d}\n", i)
+	}
+	response, err := client.LoadSharded(strings.NewReader(data.String()), 3)
+	if err != nil {
+		t.Fatalf("sharded load failed: %v", err)
+	}
+
+	// Shards 0 and 2 go to the first endpoint, shard 1 to the second
+	if first.requests != 2 || second.requests != 1 {
+		t.Errorf("expected 2 and 1 requests, got %d and %d", first.requests, second.requests)
+	}
+	if total := first.rows[0] + first.rows[1] + second.rows[0]; total != 10 {
+		t.Errorf("expected 10 rows across shards, got %d", total)
+	}
+	if response.Status != loader.SUCCESS || response.Resp.NumberLoadedRows != 10 || response.Resp.LoadBytes != int64(data.Len()) {
+		t.Errorf("unexpected aggregate response: %+v", response)
+	}
+	if response.Resp.LoadTimeMs != 4 {
+		t.Errorf("expected the slowest shard load time 4, got %d", response.Resp.LoadTimeMs)
+	}
+	if labels := strings.Split(response.Resp.Label, ","); len(labels) != 3 {
+		t.Errorf("expected 3 shard labels, got %q", response.Resp.Label)
+	}
+}
+
+func TestLoadShardedFailsIfAnyShardFails(t *testing.T) {
+	healthy, broken := &shardServer{}, &shardServer{fail: true}
+	cfg := newTestConfig(newMockServer(t, healthy.handle))
+	cfg.Endpoints = append(cfg.Endpoints, newMockServer(t, broken.handle).URL)
+	client, err := NewDorisClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	response, err := client.LoadSharded(strings.NewReader("1\n2\n3\n4\n"), 2)
+	if err == nil {
+		t.Fatalf("expected an error when a shard fails")
+	}
+	if response.Status != loader.FAILURE || !strings.Contains(response.ErrorMessage, "shard 1") {
+		t.Errorf("unexpected aggregate response: %+v", response)
+	}
+}
+
+func TestSplitRecords(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		format   config.Format
+		shards   int
+		expected []string
+	}{
+		{
+			name:     "json lines",
+			data:     "{\"a\":1}\n{\"a\":2}\n{\"a\":3}\n",
+			format:   &config.JSONFormat{Type: config.JSONObjectLine},
+			shards:   2,
+			expected: []string{"{\"a\":1}\n{\"a\":2}\n", "{\"a\":3}\n"},
+		},
+		{
+			name:     "json array",
+			data:     `[{"a":1}, {"a":[2,3]}, {"a":"x,y"}]`,
+			format:   &config.JSONFormat{Type: config.JSONArray},
+			shards:   3,
+			expected: []string{`[{"a":1}]`, `[{"a":[2,3]}]`, `[{"a":"x,y"}]`},
+		},
+		{
+			name:     "csv with newline delimiter",
+			data:     "1,a\n2,b\n3,c\n4,d",
+			format:   &config.CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"},
+			shards:   2,
+			expected: []string{"1,a\n2,b\n", "3,c\n4,d\n"},
+		},
+		{
+			name:     "more shards than records",
+			data:     "1,a|2,b|",
+			format:   &config.CSVFormat{ColumnSeparator: ",", LineDelimiter: "|"},
+			shards:   5,
+			expected: []string{"1,a|", "2,b|"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parts, err := splitRecords([]byte(tc.data), tc.format, tc.shards)
+			if err != nil {
+				t.Fatalf("failed to split: %v", err)
+			}
+			got := make([]string, 0, len(parts))
+			for _, part := range parts {
+				got = append(got, string(part))
+			}
+			if strings.Join(got, "#") != strings.Join(tc.expected, "#") {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+)
+
+// CSVFormatBuilder builds a CSVFormat with a fluent API
+// Usage: NewCSVFormat().WithColumnSeparator(",").WithLineDelimiter("\n").WithEnclose('"').Build()
+type CSVFormatBuilder struct {
+	format CSVFormat
+}
+
+// NewCSVFormat creates a builder starting from a comma column separator and a newline line delimiter
+func NewCSVFormat() *CSVFormatBuilder {
+	return &CSVFormatBuilder{
+		format: CSVFormat{
+			ColumnSeparator: ",",
+			LineDelimiter:   "\n",
+		},
+	}
+}
+
+// WithColumnSeparator sets the single byte separating the columns, e.g. "," or "\t"
+// Build a CSVFormat directly for a separator of several bytes
+func (b *CSVFormatBuilder) WithColumnSeparator(separator string) *CSVFormatBuilder {
+	b.format.ColumnSeparator = separator
+	return b
+}
+
+// WithLineDelimiter sets the delimiter of the lines, e.g. "\n" or "\r\n"
+func (b *CSVFormatBuilder) WithLineDelimiter(delimiter string) *CSVFormatBuilder {
+	b.format.LineDelimiter = delimiter
+	return b
+}
+
+// WithEnclose sets the character enclosing the fields holding separators, e.g. '"'
+func (b *CSVFormatBuilder) WithEnclose(enclose byte) *CSVFormatBuilder {
+	b.format.Enclose = enclose
+	return b
+}
+
+// Build validates the values and returns a new CSVFormat
+func (b *CSVFormatBuilder) Build() (*CSVFormat, error) {
+	if len(b.format.ColumnSeparator) != 1 {
+		return nil, fmt.Errorf("csv columnSeparator must be a single byte, got %q", b.format.ColumnSeparator)
+	}
+	if err := b.format.Validate(); err != nil {
+		return nil, err
+	}
+
+	format := b.format
+	return &format, nil
+}
@@ -0,0 +1,69 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVFormatBuilderValues(t *testing.T) {
+	format, err := NewCSVFormat().WithColumnSeparator("\t").WithLineDelimiter("\r\n").WithEnclose('"').Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := CSVFormat{ColumnSeparator: "\t", LineDelimiter: "\r\n", Enclose: '"'}
+	if *format != expected {
+		t.Errorf("expected %+v, got %+v", expected, *format)
+	}
+}
+
+func TestCSVFormatBuilderDefaults(t *testing.T) {
+	format, err := NewCSVFormat().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"}
+	if *format != expected {
+		t.Errorf("expected %+v, got %+v", expected, *format)
+	}
+}
+
+func TestCSVFormatBuilderValidation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *CSVFormatBuilder
+		wantErr string
+	}{
+		{name: "empty column separator", builder: NewCSVFormat().WithColumnSeparator(""), wantErr: "single byte"},
+		{name: "multi-byte column separator", builder: NewCSVFormat().WithColumnSeparator("||"), wantErr: "single byte"},
+		{name: "empty line delimiter", builder: NewCSVFormat().WithLineDelimiter(""), wantErr: "cannot be empty"},
+		{name: "literal escape", builder: NewCSVFormat().WithLineDelimiter(`\n`), wantErr: "backslash"},
+		{name: "overlapping separators", builder: NewCSVFormat().WithColumnSeparator("\n"), wantErr: "overlap"},
+		{name: "enclose in separator", builder: NewCSVFormat().WithEnclose(','), wantErr: "enclose"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			format, err := tc.builder.Build()
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected error containing %q, got %v (format %+v)", tc.wantErr, err, format)
+			}
+		})
+	}
+}
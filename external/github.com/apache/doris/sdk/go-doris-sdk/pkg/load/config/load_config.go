@@ -0,0 +1,888 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/util"
+)
+
+// Format interface defines the data format for stream load
+type Format interface {
+	// GetFormatType returns the format type (json or csv)
+	GetFormatType() string
+	// GetOptions returns format-specific options as map for headers
+	GetOptions() map[string]string
+	// ContentType returns the Content-Type header of the data
+	ContentType() string
+	// Validate checks the format-specific fields, it is called by Config.ValidateInternal
+	Validate() error
+}
+
+// JSONFormatType defines JSON format subtypes
+type JSONFormatType string
+
+const (
+	JSONObjectLine JSONFormatType = "object_line" // JSON objects separated by newlines
+	JSONArray      JSONFormatType = "array"       // JSON array
+)
+
+// JSONFormat represents JSON format configuration
+// Usage: &JSONFormat{Type: JSONObjectLine} or &JSONFormat{Type: JSONArray}
+type JSONFormat struct {
+	Type JSONFormatType
+	// Columns are sent as the "columns" header, mapping the JSON keys of the same names to the table columns
+	// when the table columns are in a different order, nil leaves the mapping to Doris. See JSONColumns to derive them
+	Columns []string
+	// AutoColumns are generated by Doris, e.g. the auto-increment key of an append-only table, so they are left out
+	// of the columns header even when listed in Columns. Names are matched case-insensitively like Doris columns
+	AutoColumns []string
+	// NumAsString makes Doris parse JSON numbers as strings, sent as "num_as_string", so that integers beyond 2^53,
+	// e.g. int64 IDs, keep their precision instead of going through a double
+	NumAsString bool
+}
+
+// GetFormatType implements Format interface
+func (f *JSONFormat) GetFormatType() string {
+	return "json"
+}
+
+// ContentType implements Format interface
+func (f *JSONFormat) ContentType() string {
+	return "application/json"
+}
+
+// GetOptions implements Format interface - returns headers for JSON format
+// Objects on separate lines are read with read_json_by_line, an array with strip_outer_array. The array also sends
+// read_json_by_line=false, as Doris versions where it defaults to true would read an array spanning lines line by line
+func (f *JSONFormat) GetOptions() map[string]string {
+	options := make(map[string]string)
+	options["format"] = "json"
+
+	switch f.Type {
+	case JSONObjectLine:
+		options["read_json_by_line"] = "true"
+	case JSONArray:
+		options["strip_outer_array"] = "true"
+		options["read_json_by_line"] = "false"
+	}
+	if f.NumAsString {
+		options["num_as_string"] = "true"
+	}
+	if columns := f.loadedColumns(); len(columns) > 0 {
+		options["columns"] = strings.Join(columns, ",")
+	}
+
+	return options
+}
+
+// loadedColumns returns the Columns without the AutoColumns
+func (f *JSONFormat) loadedColumns() []string {
+	if len(f.AutoColumns) == 0 {
+		return f.Columns
+	}
+	columns := make([]string, 0, len(f.Columns))
+	for _, column := range f.Columns {
+		if !containsFold(f.AutoColumns, column) {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// containsFold reports whether the names contain the name, ignoring case
+func containsFold(names []string, name string) bool {
+	for _, candidate := range names {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate implements Format interface - checks the type and that the columns can be sent in the columns header
+func (f *JSONFormat) Validate() error {
+	if f.Type != JSONObjectLine && f.Type != JSONArray {
+		return fmt.Errorf("json type must be %s or %s, got %q", JSONObjectLine, JSONArray, f.Type)
+	}
+	for _, column := range f.Columns {
+		if strings.TrimSpace(column) == "" || strings.Contains(column, ",") {
+			return fmt.Errorf("json columns cannot be empty or contain a comma, got %q", column)
+		}
+	}
+	for _, column := range f.AutoColumns {
+		if strings.TrimSpace(column) == "" {
+			return fmt.Errorf("json auto columns cannot be empty")
+		}
+	}
+	if len(f.Columns) > 0 && len(f.loadedColumns()) == 0 {
+		return fmt.Errorf("json columns cannot all be auto columns")
+	}
+	return nil
+}
+
+// JSONColumns returns the keys of a JSON object, e.g. the first record of the data, in the order they appear
+func JSONColumns(record []byte) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(record))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("record is not a JSON object")
+	}
+
+	var columns []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON object: %w", err)
+		}
+		columns = append(columns, token.(string))
+		// Skip the value
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("invalid JSON object: %w", err)
+		}
+	}
+	return columns, nil
+}
+
+// CSVFormat represents CSV format configuration
+// The separators are the actual characters of the data, they are escaped when sent in the headers
+// Usage: &CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"}
+type CSVFormat struct {
+	ColumnSeparator string // e.g. ",", "\t" or "\x01", multiple characters are allowed
+	LineDelimiter   string // e.g. "\n" or "\r\n", multiple characters are allowed
+	Enclose         byte   // Character enclosing the fields holding separators, e.g. '"', zero sends none
+}
+
+// GetFormatType implements Format interface
+func (f *CSVFormat) GetFormatType() string {
+	return "csv"
+}
+
+// ContentType implements Format interface
+func (f *CSVFormat) ContentType() string {
+	return "text/plain"
+}
+
+// GetOptions implements Format interface - returns headers for CSV format
+func (f *CSVFormat) GetOptions() map[string]string {
+	options := make(map[string]string)
+	options["format"] = "csv"
+	options["column_separator"] = escapeDelimiter(f.ColumnSeparator)
+	options["line_delimiter"] = escapeDelimiter(f.LineDelimiter)
+	if f.Enclose != 0 {
+		options["enclose"] = escapeDelimiter(string(f.Enclose))
+	}
+	return options
+}
+
+// Validate implements Format interface - checks that the separators are set and unambiguous
+func (f *CSVFormat) Validate() error {
+	if f.ColumnSeparator == "" || f.LineDelimiter == "" {
+		return fmt.Errorf("csv columnSeparator and lineDelimiter cannot be empty")
+	}
+	// A backslash is almost always an escape written literally, e.g. `\n` instead of "\n"
+	if strings.Contains(f.ColumnSeparator, `\`) || strings.Contains(f.LineDelimiter, `\`) {
+		return fmt.Errorf("csv columnSeparator %q and lineDelimiter %q cannot contain a backslash, use the actual characters, e.g. \"\\n\" for a newline",
+			f.ColumnSeparator, f.LineDelimiter)
+	}
+	if strings.Contains(f.ColumnSeparator, f.LineDelimiter) || strings.Contains(f.LineDelimiter, f.ColumnSeparator) {
+		return fmt.Errorf("csv columnSeparator %q and lineDelimiter %q overlap", f.ColumnSeparator, f.LineDelimiter)
+	}
+	if f.Enclose != 0 && (f.Enclose == '\\' || strings.IndexByte(f.ColumnSeparator+f.LineDelimiter, f.Enclose) >= 0) {
+		return fmt.Errorf("csv enclose %q cannot be a backslash or part of the separators", f.Enclose)
+	}
+	return nil
+}
+
+// escapeDelimiter converts a separator to the form Doris expects in the headers,
+// newlines, carriage returns and tabs are written as \n, \r and \t and other control characters as \xHH
+func escapeDelimiter(delimiter string) string {
+	var sb strings.Builder
+	for i := 0; i < len(delimiter); i++ {
+		switch c := delimiter[i]; {
+		case c == '\n':
+			sb.WriteString(`\n`)
+		case c == '\r':
+			sb.WriteString(`\r`)
+		case c == '\t':
+			sb.WriteString(`\t`)
+		case c < 0x20 || c == 0x7f:
+			fmt.Fprintf(&sb, `\x%02x`, c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// Timezone forms accepted by Doris: IANA names like "Asia/Shanghai" or "UTC", and offsets like "+08:00"
+var (
+	timezoneNamePattern   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_+-]*(/[A-Za-z0-9_+-]+)*$`)
+	timezoneOffsetPattern = regexp.MustCompile(`^[+-]\d{2}:\d{2}$`)
+)
+
+// labelPattern matches the characters Doris accepts in labels
+var labelPattern = regexp.MustCompile(`^[-_A-Za-z0-9:]+$`)
+
+// reservedHeaders are set by the SDK and cannot be overridden by ExtraHeaders
+var reservedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Host":                true,
+	"Content-Length":      true,
+	"Content-Type":        true,
+	"Transfer-Encoding":   true,
+	"Expect":              true,
+	"User-Agent":          true,
+	"Label":               true,
+	"X-Request-Id":        true,
+}
+
+// ValidateExtraHeaders checks that extra headers have names and do not override the headers managed by the SDK
+func ValidateExtraHeaders(headers map[string]string) error {
+	for name := range headers {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("extraHeaders cannot contain empty header names")
+		}
+		if reservedHeaders[http.CanonicalHeaderKey(name)] {
+			return fmt.Errorf("extraHeaders cannot override the %s header managed by the SDK", name)
+		}
+	}
+	return nil
+}
+
+// constantColumnPattern matches the column names accepted in ConstantColumns, which are sent unquoted
+var constantColumnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// pathPrefixPattern matches a PathPrefix: one or more path segments without a trailing slash
+var pathPrefixPattern = regexp.MustCompile(`^(/[-._~A-Za-z0-9]+)+$`)
+
+// envReferencePattern matches a value read from an environment variable, e.g. "${ENV:DORIS_PASSWORD}"
+var envReferencePattern = regexp.MustCompile(`^\$\{ENV:([A-Za-z_][A-Za-z0-9_]*)\}$`)
+
+// ResolveEnv returns the value of the environment variable a "${ENV:NAME}" value refers to,
+// other values are returned as they are. It fails when the variable is not set
+func ResolveEnv(value string) (string, error) {
+	match := envReferencePattern.FindStringSubmatch(value)
+	if match == nil {
+		return value, nil
+	}
+	resolved, ok := os.LookupEnv(match[1])
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", match[1])
+	}
+	return resolved, nil
+}
+
+// DefaultMaxLabelLength is the longest label Doris accepts, used when MaxLabelLength is zero
+const DefaultMaxLabelLength = 128
+
+// MinMaxLabelLength leaves room for the unique suffix of generated labels
+const MinMaxLabelLength = 64
+
+// DeleteSignColumn is the hidden column of unique key tables marking the rows to delete
+const DeleteSignColumn = "__DORIS_DELETE_SIGN__"
+
+// DefaultEndpointWeight is the weight of the endpoints missing from EndpointWeights
+const DefaultEndpointWeight = 1
+
+// DefaultEndpointRefreshInterval is the minimum time between two refreshes of the EndpointProvider
+const DefaultEndpointRefreshInterval = 30 * time.Second
+
+// DefaultMaxBufferBytes is the size up to which the data of a load is held in memory when MaxBufferBytes is zero
+const DefaultMaxBufferBytes int64 = 8 << 20
+
+// Version is the version of the SDK
+const Version = "1.0.0"
+
+// DefaultUserAgent is the User-Agent header sent when UserAgent is empty
+const DefaultUserAgent = "go-doris-sdk/" + Version
+
+// DefaultMaxResponseBytes is the largest stream load response body read when MaxResponseBytes is zero
+const DefaultMaxResponseBytes int64 = 1 << 20
+
+// GroupCommitMode defines the group commit mode
+type GroupCommitMode int
+
+const (
+	// SYNC represents synchronous group commit mode
+	SYNC GroupCommitMode = iota
+	// ASYNC represents asynchronous group commit mode
+	ASYNC
+	// OFF represents disabled group commit mode
+	OFF
+)
+
+// Retry contains configuration for retry attempts when loading data
+type Retry struct {
+	MaxRetryTimes  int   // Maximum number of retry attempts
+	BaseIntervalMs int64 // Base interval in milliseconds for exponential backoff
+	MaxTotalTimeMs int64 // Maximum total time for all retries in milliseconds
+	Jitter         bool  // Randomize each backoff interval between half and full length to spread out retries
+	MaxIntervalMs  int64 // Cap of a single backoff interval in milliseconds, whatever the attempt, zero means no cap
+}
+
+// CircuitState is the state of the circuit breaker of an endpoint
+type CircuitState int
+
+const (
+	// CircuitClosed sends loads to the endpoint
+	CircuitClosed CircuitState = iota
+	// CircuitOpen skips the endpoint until the reset timeout has passed
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe load through to check if the endpoint recovered
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures the per-endpoint circuit breakers
+// An endpoint failing FailureThreshold consecutive attempts with a connection error or an unavailable response is
+// skipped for ResetTimeout, after which one probe load decides whether it is closed again or stays open
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures opening the breaker of an endpoint
+	ResetTimeout     time.Duration // Time an open breaker waits before letting a probe through
+	// OnStateChange is called on every state change of the breaker of an endpoint, nil disables it
+	OnStateChange func(endpoint string, from, to CircuitState)
+}
+
+// ConnectionPool contains the connection pool limits of a client, zero values use the defaults of the shared pool
+type ConnectionPool struct {
+	MaxIdleConnsPerHost int // Idle connections kept per host, default 30
+	MaxConnsPerHost     int // Active and idle connections per host, excess requests wait, default 50
+	MaxIdleConns        int // Idle connections kept for all hosts, default 50
+	// Idle connections are closed after this time, so that a recycled FE does not fail the next request, default 90s
+	IdleConnTimeout time.Duration
+}
+
+// LoadTrace contains the timing of the phases of a single load attempt, collected with httptrace
+// Phases that did not happen, e.g. DNS and connect on a reused connection, have a zero duration
+type LoadTrace struct {
+	Endpoint     string        // Host of the endpoint the request was sent to
+	Label        string        // Label of the load, empty under group commit
+	Attempt      int           // Attempt number, starting from 1
+	ReusedConn   bool          // Whether an idle connection was reused
+	DNS          time.Duration // DNS lookup
+	Connect      time.Duration // TCP connection
+	TLSHandshake time.Duration // TLS handshake
+	FirstByte    time.Duration // From sending the request to the first response byte
+	Total        time.Duration // Whole request including reading the response
+}
+
+// Config contains all configuration for stream load operations
+type Config struct {
+	Endpoints []string
+	// User and Password are read from an environment variable when they have the form "${ENV:NAME}"
+	User        string
+	Password    string
+	Database    string
+	Table       string
+	LabelPrefix string
+	// Label is sent with the first attempt of a load, so that a load sent again by the caller with the same label
+	// is not committed twice. Retries of the client use new labels, so the deduplication only covers the loads of
+	// the caller, not the retries of a single load
+	Label       string
+	Format      Format // Can be &JSONFormat{...} or &CSVFormat{...}
+	Retry       *Retry
+	GroupCommit GroupCommitMode
+	Options     map[string]string
+
+	// ExtraHeaders are sent with every stream load, e.g. tenant IDs or routing hints for a gateway in front of Doris
+	// They cannot override the headers managed by the SDK, stream load options set by the SDK take precedence
+	ExtraHeaders map[string]string
+
+	// SelectDB Cloud / compute-storage decoupled deployments, empty values are not sent
+	Warehouse string // Sent as the "warehouse" header
+	Cluster   string // Compute cluster sent as the "cloud_cluster" header
+
+	// SlowLoadThreshold emits a warning when a single Load takes longer than it, zero disables the warning
+	SlowLoadThreshold time.Duration
+
+	// LogErrorURLSample fetches the beginning of the rejected rows at the ErrorURL of a load with filtered rows
+	// and logs it as a warning, at most once a minute per client
+	LogErrorURLSample bool
+
+	// TraceIDFunc generates the trace ID of each Load, which is sent in the X-Request-Id header and added to its logs
+	TraceIDFunc func() string
+
+	// OnTrace receives the per-phase timing of each load attempt, nil disables tracing without any overhead
+	OnTrace func(trace LoadTrace)
+
+	// OnRedirect is called with the hosts when a stream load is redirected, e.g. by FE to the BE handling the load
+	// It is not called with an HTTPClient given in the configuration
+	OnRedirect func(from, to string)
+
+	// LoadTimeoutSeconds is the Doris side timeout of a load, sent as the "timeout" header, nil uses the Doris default
+	// It takes precedence over a "timeout" entry in Options
+	LoadTimeoutSeconds *int
+
+	// ExecMemLimitBytes is the memory limit of a load, sent as the "exec_mem_limit" header, nil uses the Doris default
+	// It takes precedence over an "exec_mem_limit" entry in Options. Under group commit the data is written by an
+	// internal load shared with other requests, so the limit of a single request does not bound its memory
+	ExecMemLimitBytes *int64
+
+	// Timezone used by Doris to parse time values, sent as the "timezone" header, empty uses the Doris default
+	// e.g. "Asia/Shanghai", "UTC" or "+08:00"
+	Timezone string
+
+	// DeleteSign tells Doris that the data of a unique key table carries the DeleteSignColumn, 1 for the rows to delete
+	// and 0 for the rows to write. It is sent as the "hidden_columns" header, or added to an explicit columns list
+	DeleteSign bool
+
+	// ConstantColumns set columns missing from the data to the same value in every row, e.g. {"env": "prod"}, through
+	// expressions like env='prod' appended to the columns header. Doris then loads only the listed columns, so an
+	// explicit columns list is required, from JSONFormat.Columns or the "columns" entry of Options
+	ConstantColumns map[string]string
+
+	// HTTPTimeout is the client side timeout of a whole request, zero uses util.DefaultHTTPTimeout (120 seconds)
+	// It should be longer than LoadTimeoutSeconds, otherwise the client gives up on loads Doris is still running
+	HTTPTimeout time.Duration
+
+	// ConnectionPool gives the client its own connection pool with these limits
+	// nil shares one pool between all clients of the process
+	ConnectionPool *ConnectionPool
+
+	// CircuitBreaker skips endpoints that fail consistently, nil disables it
+	CircuitBreaker *CircuitBreakerConfig
+
+	// PathPrefix is prepended to the path of the Doris HTTP APIs, for gateways exposing them under a base path,
+	// e.g. "/doris" sends stream loads to <endpoint>/doris/api/{db}/{table}/_stream_load. Empty uses the default paths
+	PathPrefix string
+
+	// EndpointProvider returns the current FE endpoints, e.g. from service discovery. They are fetched when the client
+	// is created and refreshed by loads once EndpointRefreshInterval has passed, replacing Endpoints. A refresh failing
+	// or returning invalid endpoints keeps the previous ones
+	EndpointProvider func() ([]string, error)
+	// EndpointRefreshInterval is the minimum time between two refreshes, zero uses DefaultEndpointRefreshInterval
+	EndpointRefreshInterval time.Duration
+	// EndpointWeights spreads the loads over the endpoints in proportion to their weight, e.g. to send fewer loads to
+	// smaller FEs. Endpoints missing from the map weigh DefaultEndpointWeight, so nil spreads the loads evenly
+	EndpointWeights map[string]int
+
+	// Client certificate presented to HTTPS endpoints requiring mutual TLS, either as PEM files or loaded
+	// ClientCertificate takes precedence over the files. Either gives the client its own connection pool
+	ClientCertFile    string
+	ClientKeyFile     string
+	ClientCertificate *tls.Certificate
+
+	// Proxy is the URL of the HTTP proxy requests are sent through, e.g. "http://proxy.example.com:3128"
+	// Empty sends requests directly, a proxy gives the client its own connection pool
+	Proxy string
+
+	// IsolatedTransport gives the client its own connection pool even without other transport settings,
+	// so that its connections are not shared with the other clients of the process
+	IsolatedTransport bool
+
+	// HTTPClient is used for all requests instead of a client built by the SDK, e.g. to share a transport between
+	// some clients or to inject a test transport. HTTPTimeout and the transport settings above are then ignored
+	HTTPClient *http.Client
+
+	// MaxBufferBytes is the size up to which the data of a load is held in memory, zero uses DefaultMaxBufferBytes
+	// Buffered data is sent with a Content-Length and can always be retried. Larger seekable readers are rewound
+	// for each attempt, larger non-seekable readers are streamed and cannot be retried once sent
+	MaxBufferBytes int64
+
+	// AllowEmptyLoad makes a load without data succeed with zero rows instead of sending it, nil means true
+	// Data made of whitespace only, or an empty array with the JSON array format, counts as no data
+	AllowEmptyLoad *bool
+
+	// MaxLabelLength is the longest label sent, zero uses DefaultMaxLabelLength
+	// Generated labels longer than this have their prefix truncated, keeping the unique suffix
+	MaxLabelLength int
+
+	// UserAgent is sent as the User-Agent header to identify the client in FE access logs, empty uses DefaultUserAgent
+	UserAgent string
+
+	// MaxResponseBytes is the largest response body read from Doris, zero uses DefaultMaxResponseBytes
+	// Larger responses fail the load instead of being read into memory
+	MaxResponseBytes int64
+
+	// StrictLabelPolicy rejects configurations combining group commit with Label or LabelPrefix
+	// When false, labels are removed from group commit requests with a warning
+	StrictLabelPolicy bool
+
+	// RetryableMessages are substrings of the Status or Message of failed responses that mark transient errors,
+	// e.g. "publish timeout". Matching responses are retried even if they would otherwise be terminal
+	// The match ignores case, the built-in transient patterns always apply
+	RetryableMessages []string
+}
+
+// String returns a printable form of the configuration with the password and sensitive options masked
+func (c Config) String() string {
+	return fmt.Sprintf("{Endpoints:%v User:%s Password:%s Database:%s Table:%s LabelPrefix:%s Label:%s GroupCommit:%d Options:%v}",
+		c.Endpoints, c.User, util.Redact(c.Password), c.Database, c.Table, c.LabelPrefix, c.Label, c.GroupCommit, util.RedactMap(c.Options))
+}
+
+// ValidationError reports all the problems found in a configuration at once
+type ValidationError struct {
+	Errors []error
+}
+
+// Error returns all the problems separated by semicolons
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the individual problems, so that errors.Is and errors.As check each of them
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+// ValidateInternal validates the configuration
+// All problems are reported together as a *ValidationError
+func (c *Config) ValidateInternal() error {
+	var errs []error
+
+	if c.User == "" {
+		errs = append(errs, fmt.Errorf("user cannot be empty"))
+	}
+
+	if c.Database == "" {
+		errs = append(errs, fmt.Errorf("database cannot be empty"))
+	}
+
+	if c.Table == "" {
+		errs = append(errs, fmt.Errorf("table cannot be empty"))
+	}
+
+	if err := ValidateEndpoints(c.Endpoints); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.Format == nil {
+		errs = append(errs, fmt.Errorf("format cannot be nil"))
+	} else if err := c.Format.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.StrictLabelPolicy && c.isGroupCommitEnabled() && (c.Label != "" || c.LabelPrefix != "") {
+		errs = append(errs, fmt.Errorf("label and labelPrefix cannot be used with group commit when StrictLabelPolicy is enabled"))
+	}
+
+	if c.MaxLabelLength != 0 && c.MaxLabelLength < MinMaxLabelLength {
+		errs = append(errs, fmt.Errorf("maxLabelLength must be at least %d", MinMaxLabelLength))
+	}
+
+	if c.Label != "" && (!labelPattern.MatchString(c.Label) || len(c.Label) > c.GetMaxLabelLength()) {
+		errs = append(errs, fmt.Errorf("label must be at most %d characters among letters, digits, '-', '_' and ':'", c.GetMaxLabelLength()))
+	}
+
+	if c.SlowLoadThreshold < 0 {
+		errs = append(errs, fmt.Errorf("slowLoadThreshold cannot be negative"))
+	}
+
+	if c.LoadTimeoutSeconds != nil && *c.LoadTimeoutSeconds <= 0 {
+		errs = append(errs, fmt.Errorf("loadTimeoutSeconds must be positive"))
+	}
+
+	if c.ExecMemLimitBytes != nil && *c.ExecMemLimitBytes <= 0 {
+		errs = append(errs, fmt.Errorf("execMemLimitBytes must be positive"))
+	}
+
+	if c.Timezone != "" && !timezoneNamePattern.MatchString(c.Timezone) && !timezoneOffsetPattern.MatchString(c.Timezone) {
+		errs = append(errs, fmt.Errorf("invalid timezone %q: must be an IANA name like Asia/Shanghai or an offset like +08:00", c.Timezone))
+	}
+
+	if c.PathPrefix != "" && !pathPrefixPattern.MatchString(c.PathPrefix) {
+		errs = append(errs, fmt.Errorf("invalid pathPrefix %q: must start with / and contain only path segments, e.g. /doris", c.PathPrefix))
+	}
+
+	if c.EndpointRefreshInterval < 0 {
+		errs = append(errs, fmt.Errorf("endpointRefreshInterval cannot be negative"))
+	}
+
+	for endpoint, weight := range c.EndpointWeights {
+		if weight <= 0 {
+			errs = append(errs, fmt.Errorf("endpointWeights of %q must be positive", endpoint))
+		}
+	}
+
+	if len(c.ConstantColumns) > 0 {
+		if !c.hasColumns() {
+			errs = append(errs, fmt.Errorf("constantColumns require an explicit columns list"))
+		}
+		for name := range c.ConstantColumns {
+			if !constantColumnPattern.MatchString(name) {
+				errs = append(errs, fmt.Errorf("invalid constantColumns name %q: must be a column name like env", name))
+			}
+		}
+	}
+
+	if c.HTTPTimeout < 0 {
+		errs = append(errs, fmt.Errorf("httpTimeout cannot be negative"))
+	}
+
+	if c.ConnectionPool != nil && (c.ConnectionPool.MaxIdleConnsPerHost < 0 || c.ConnectionPool.MaxConnsPerHost < 0 ||
+		c.ConnectionPool.MaxIdleConns < 0 || c.ConnectionPool.IdleConnTimeout < 0) {
+		errs = append(errs, fmt.Errorf("connectionPool limits cannot be negative"))
+	}
+
+	if c.CircuitBreaker != nil {
+		if c.CircuitBreaker.FailureThreshold <= 0 {
+			errs = append(errs, fmt.Errorf("circuitBreaker failureThreshold must be positive"))
+		}
+		if c.CircuitBreaker.ResetTimeout <= 0 {
+			errs = append(errs, fmt.Errorf("circuitBreaker resetTimeout must be positive"))
+		}
+	}
+
+	if c.MaxBufferBytes < 0 {
+		errs = append(errs, fmt.Errorf("maxBufferBytes cannot be negative"))
+	}
+
+	if c.MaxResponseBytes < 0 {
+		errs = append(errs, fmt.Errorf("maxResponseBytes cannot be negative"))
+	}
+
+	if err := ValidateExtraHeaders(c.ExtraHeaders); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, message := range c.RetryableMessages {
+		if strings.TrimSpace(message) == "" {
+			errs = append(errs, fmt.Errorf("retryableMessages cannot contain empty entries"))
+			break
+		}
+	}
+
+	if c.Proxy != "" {
+		if proxyURL, err := url.Parse(c.Proxy); err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid proxy %q: proxy must be a URL with scheme and host, e.g. http://127.0.0.1:3128", c.Proxy))
+		}
+	}
+
+	if (c.ClientCertFile == "") != (c.ClientKeyFile == "") {
+		errs = append(errs, fmt.Errorf("clientCertFile and clientKeyFile must be set together"))
+	}
+
+	if c.Retry != nil {
+		if c.Retry.MaxRetryTimes < 0 {
+			errs = append(errs, fmt.Errorf("maxRetryTimes cannot be negative"))
+		}
+		if c.Retry.BaseIntervalMs < 0 {
+			errs = append(errs, fmt.Errorf("retryIntervalMs cannot be negative"))
+		}
+		if c.Retry.MaxTotalTimeMs < 0 {
+			errs = append(errs, fmt.Errorf("maxTotalTimeMs cannot be negative"))
+		}
+		if c.Retry.MaxIntervalMs < 0 {
+			errs = append(errs, fmt.Errorf("maxIntervalMs cannot be negative"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// hasColumns reports whether the loads send a columns header, from Options or the format
+func (c *Config) hasColumns() bool {
+	if _, ok := c.Options["columns"]; ok {
+		return true
+	}
+	if c.Format == nil {
+		return false
+	}
+	_, ok := c.Format.GetOptions()["columns"]
+	return ok
+}
+
+// ClientCertificates returns the client certificate to present for mutual TLS, nil when none is configured
+func (c *Config) ClientCertificates() ([]tls.Certificate, error) {
+	if c.ClientCertificate != nil {
+		return []tls.Certificate{*c.ClientCertificate}, nil
+	}
+	if c.ClientCertFile == "" {
+		return nil, nil
+	}
+	certificate, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	return []tls.Certificate{certificate}, nil
+}
+
+// ProxyURL returns the parsed Proxy, nil when requests are sent directly
+func (c *Config) ProxyURL() (*url.URL, error) {
+	if c.Proxy == "" {
+		return nil, nil
+	}
+	return url.Parse(c.Proxy)
+}
+
+// GetHTTPTimeout returns the effective HTTPTimeout
+func (c *Config) GetHTTPTimeout() time.Duration {
+	if c.HTTPTimeout <= 0 {
+		return util.DefaultHTTPTimeout
+	}
+	return c.HTTPTimeout
+}
+
+// GetMaxBufferBytes returns the effective MaxBufferBytes
+func (c *Config) GetMaxBufferBytes() int64 {
+	if c.MaxBufferBytes <= 0 {
+		return DefaultMaxBufferBytes
+	}
+	return c.MaxBufferBytes
+}
+
+// GetAllowEmptyLoad returns the effective AllowEmptyLoad
+func (c *Config) GetAllowEmptyLoad() bool {
+	return c.AllowEmptyLoad == nil || *c.AllowEmptyLoad
+}
+
+// GetMaxLabelLength returns the longest label sent
+func (c *Config) GetMaxLabelLength() int {
+	if c.MaxLabelLength <= 0 {
+		return DefaultMaxLabelLength
+	}
+	return c.MaxLabelLength
+}
+
+// SetAllowedFailures sets the "max_filter_ratio" option so that a load of total rows succeeds with up to allowed
+// rows filtered out. Doris applies the ratio to the actual rows of each load, so it should match their expected size
+func (c *Config) SetAllowedFailures(total, allowed int) error {
+	if total <= 0 {
+		return fmt.Errorf("total rows must be positive to compute max_filter_ratio, got %d", total)
+	}
+	if allowed < 0 {
+		return fmt.Errorf("allowed failures cannot be negative, got %d", allowed)
+	}
+	if allowed > total {
+		allowed = total
+	}
+
+	options := make(map[string]string, len(c.Options)+1)
+	for key, value := range c.Options {
+		options[key] = value
+	}
+	options["max_filter_ratio"] = strconv.FormatFloat(float64(allowed)/float64(total), 'f', -1, 64)
+	c.Options = options
+	return nil
+}
+
+// PickEndpoint randomly selects one of the endpoints in proportion to its weight in EndpointWeights
+func (c *Config) PickEndpoint(endpoints []string) string {
+	if len(endpoints) == 0 {
+		return ""
+	}
+	if len(c.EndpointWeights) == 0 {
+		return endpoints[rand.Intn(len(endpoints))]
+	}
+
+	total := 0
+	for _, endpoint := range endpoints {
+		total += c.endpointWeight(endpoint)
+	}
+	n := rand.Intn(total)
+	for _, endpoint := range endpoints {
+		if n -= c.endpointWeight(endpoint); n < 0 {
+			return endpoint
+		}
+	}
+	return endpoints[len(endpoints)-1]
+}
+
+// endpointWeight returns the weight of the endpoint
+func (c *Config) endpointWeight(endpoint string) int {
+	if weight, ok := c.EndpointWeights[endpoint]; ok && weight > 0 {
+		return weight
+	}
+	return DefaultEndpointWeight
+}
+
+// GetEndpointRefreshInterval returns the minimum time between two refreshes of the EndpointProvider
+func (c *Config) GetEndpointRefreshInterval() time.Duration {
+	if c.EndpointRefreshInterval <= 0 {
+		return DefaultEndpointRefreshInterval
+	}
+	return c.EndpointRefreshInterval
+}
+
+// GetUserAgent returns the User-Agent header of the requests
+func (c *Config) GetUserAgent() string {
+	if c.UserAgent == "" {
+		return DefaultUserAgent
+	}
+	return c.UserAgent
+}
+
+// GetMaxResponseBytes returns the largest response body read from Doris
+func (c *Config) GetMaxResponseBytes() int64 {
+	if c.MaxResponseBytes <= 0 {
+		return DefaultMaxResponseBytes
+	}
+	return c.MaxResponseBytes
+}
+
+// isGroupCommitEnabled reports whether loads are sent in group commit mode, either by GroupCommit or by Options
+func (c *Config) isGroupCommitEnabled() bool {
+	if _, ok := c.Options["group_commit"]; ok {
+		return true
+	}
+	return c.GroupCommit != OFF
+}
+
+// ValidateEndpoints checks that every endpoint is an http or https URL with a host, e.g. http://127.0.0.1:8030
+func ValidateEndpoints(endpoints []string) error {
+	if len(endpoints) == 0 {
+		return fmt.Errorf("endpoints cannot be empty")
+	}
+
+	var invalid []string
+	for _, endpoint := range endpoints {
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil || (endpointURL.Scheme != "http" && endpointURL.Scheme != "https") || endpointURL.Host == "" {
+			invalid = append(invalid, fmt.Sprintf("%q", endpoint))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid endpoints [%s]: endpoints must be http or https URLs with a host, e.g. http://127.0.0.1:8030",
+			strings.Join(invalid, ", "))
+	}
+	return nil
+}
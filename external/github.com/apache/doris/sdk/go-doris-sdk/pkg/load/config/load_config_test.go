@@ -0,0 +1,581 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newValidConfig creates a configuration that passes validation
+func newValidConfig() *Config {
+	return &Config{
+		Endpoints: []string{"http://127.0.0.1:8030"},
+		User:      "root",
+		Database:  "test_db",
+		Table:     "test_table",
+		Format:    &JSONFormat{Type: JSONObjectLine},
+	}
+}
+
+func TestValidateInternal(t *testing.T) {
+	testCases := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{name: "valid", modify: func(cfg *Config) {}},
+		{name: "empty user", modify: func(cfg *Config) { cfg.User = "" }, wantErr: "user cannot be empty"},
+		{name: "empty database", modify: func(cfg *Config) { cfg.Database = "" }, wantErr: "database cannot be empty"},
+		{name: "empty database with group commit off", modify: func(cfg *Config) {
+			cfg.Database = ""
+			cfg.GroupCommit = OFF
+		}, wantErr: "database cannot be empty"},
+		{name: "empty table", modify: func(cfg *Config) { cfg.Table = "" }, wantErr: "table cannot be empty"},
+		{name: "empty endpoints", modify: func(cfg *Config) { cfg.Endpoints = []string{} }, wantErr: "endpoints cannot be empty"},
+		{name: "nil format", modify: func(cfg *Config) { cfg.Format = nil }, wantErr: "format cannot be nil"},
+		{name: "negative slow load threshold", modify: func(cfg *Config) { cfg.SlowLoadThreshold = -1 }, wantErr: "slowLoadThreshold cannot be negative"},
+		{name: "negative max buffer bytes", modify: func(cfg *Config) { cfg.MaxBufferBytes = -1 }, wantErr: "maxBufferBytes cannot be negative"},
+		{name: "max label length too short", modify: func(cfg *Config) { cfg.MaxLabelLength = 32 }, wantErr: "maxLabelLength must be at least 64"},
+		{name: "invalid label characters", modify: func(cfg *Config) { cfg.Label = "daily load" },
+			wantErr: "label must be at most 128 characters among letters, digits, '-', '_' and ':'"},
+		{name: "label too long", modify: func(cfg *Config) { cfg.Label = strings.Repeat("a", 129) },
+			wantErr: "label must be at most 128 characters among letters, digits, '-', '_' and ':'"},
+		{name: "negative max response bytes", modify: func(cfg *Config) { cfg.MaxResponseBytes = -1 }, wantErr: "maxResponseBytes cannot be negative"},
+		{name: "zero load timeout", modify: func(cfg *Config) { cfg.LoadTimeoutSeconds = new(int) }, wantErr: "loadTimeoutSeconds must be positive"},
+		{name: "zero exec mem limit", modify: func(cfg *Config) { cfg.ExecMemLimitBytes = new(int64) }, wantErr: "execMemLimitBytes must be positive"},
+		{name: "iana timezone", modify: func(cfg *Config) { cfg.Timezone = "America/Argentina/Buenos_Aires" }},
+		{name: "offset timezone", modify: func(cfg *Config) { cfg.Timezone = "+08:00" }},
+		{name: "utc timezone", modify: func(cfg *Config) { cfg.Timezone = "UTC" }},
+		{name: "invalid timezone", modify: func(cfg *Config) { cfg.Timezone = "Asia Shanghai" },
+			wantErr: `invalid timezone "Asia Shanghai": must be an IANA name like Asia/Shanghai or an offset like +08:00`},
+		{name: "invalid offset timezone", modify: func(cfg *Config) { cfg.Timezone = "+8" },
+			wantErr: `invalid timezone "+8": must be an IANA name like Asia/Shanghai or an offset like +08:00`},
+		{name: "negative connection pool limit", modify: func(cfg *Config) { cfg.ConnectionPool = &ConnectionPool{MaxConnsPerHost: -1} },
+			wantErr: "connectionPool limits cannot be negative"},
+		{name: "negative idle connection timeout", modify: func(cfg *Config) { cfg.ConnectionPool = &ConnectionPool{IdleConnTimeout: -time.Second} },
+			wantErr: "connectionPool limits cannot be negative"},
+		{name: "circuit breaker without threshold", modify: func(cfg *Config) {
+			cfg.CircuitBreaker = &CircuitBreakerConfig{ResetTimeout: time.Second}
+		}, wantErr: "circuitBreaker failureThreshold must be positive"},
+		{name: "circuit breaker without reset timeout", modify: func(cfg *Config) {
+			cfg.CircuitBreaker = &CircuitBreakerConfig{FailureThreshold: 3}
+		}, wantErr: "circuitBreaker resetTimeout must be positive"},
+		{name: "negative http timeout", modify: func(cfg *Config) { cfg.HTTPTimeout = -1 }, wantErr: "httpTimeout cannot be negative"},
+		{name: "negative max retry times", modify: func(cfg *Config) { cfg.Retry = &Retry{MaxRetryTimes: -1} }, wantErr: "maxRetryTimes cannot be negative"},
+		{name: "negative retry interval", modify: func(cfg *Config) { cfg.Retry = &Retry{BaseIntervalMs: -1} }, wantErr: "retryIntervalMs cannot be negative"},
+		{name: "negative max total time", modify: func(cfg *Config) { cfg.Retry = &Retry{MaxTotalTimeMs: -1} }, wantErr: "maxTotalTimeMs cannot be negative"},
+		{name: "negative max interval", modify: func(cfg *Config) { cfg.Retry = &Retry{MaxIntervalMs: -1} }, wantErr: "maxIntervalMs cannot be negative"},
+		{name: "proxy without scheme", modify: func(cfg *Config) { cfg.Proxy = "proxy.example.com:3128" }, wantErr: `invalid proxy "proxy.example.com:3128": proxy must be a URL with scheme and host, e.g. http://127.0.0.1:3128`},
+		{name: "client certificate without key", modify: func(cfg *Config) { cfg.ClientCertFile = "client.crt" }, wantErr: "clientCertFile and clientKeyFile must be set together"},
+		{name: "empty retryable message", modify: func(cfg *Config) { cfg.RetryableMessages = []string{"publish timeout", " "} }, wantErr: "retryableMessages cannot contain empty entries"},
+		{name: "extra header overriding authorization", modify: func(cfg *Config) { cfg.ExtraHeaders = map[string]string{"authorization": "Bearer x"} }, wantErr: "extraHeaders cannot override the authorization header managed by the SDK"},
+		{name: "zero endpoint weight", modify: func(cfg *Config) { cfg.EndpointWeights = map[string]int{"http://127.0.0.1:8030": 0} }, wantErr: `endpointWeights of "http://127.0.0.1:8030" must be positive`},
+		{name: "negative endpoint refresh interval", modify: func(cfg *Config) { cfg.EndpointRefreshInterval = -time.Second }, wantErr: "endpointRefreshInterval cannot be negative"},
+		{name: "path prefix without leading slash", modify: func(cfg *Config) { cfg.PathPrefix = "doris" }, wantErr: `invalid pathPrefix "doris": must start with / and contain only path segments, e.g. /doris`},
+		{name: "path prefix with trailing slash", modify: func(cfg *Config) { cfg.PathPrefix = "/doris/" }, wantErr: `invalid pathPrefix "/doris/": must start with / and contain only path segments, e.g. /doris`},
+		{name: "extra header without name", modify: func(cfg *Config) { cfg.ExtraHeaders = map[string]string{"": "x"} }, wantErr: "extraHeaders cannot contain empty header names"},
+		{name: "constant columns", modify: func(cfg *Config) {
+			cfg.Options = map[string]string{"columns": "id,message"}
+			cfg.ConstantColumns = map[string]string{"env": "prod"}
+		}},
+		{name: "constant columns without columns", modify: func(cfg *Config) { cfg.ConstantColumns = map[string]string{"env": "prod"} },
+			wantErr: "constantColumns require an explicit columns list"},
+		{name: "invalid constant column name", modify: func(cfg *Config) {
+			cfg.Format = &JSONFormat{Type: JSONObjectLine, Columns: []string{"id"}}
+			cfg.ConstantColumns = map[string]string{"env=1": "prod"}
+		}, wantErr: `invalid constantColumns name "env=1": must be a column name like env`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			tc.modify(cfg)
+			err := cfg.ValidateInternal()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateInternalReportsAllProblems(t *testing.T) {
+	cfg := &Config{
+		Endpoints: []string{"127.0.0.1:8030"},
+		Retry:     &Retry{MaxRetryTimes: -1},
+	}
+	err := cfg.ValidateInternal()
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+	}
+	if len(validationErr.Errors) != 6 {
+		t.Errorf("expected 6 problems, got %d: %v", len(validationErr.Errors), err)
+	}
+	for _, want := range []string{"user", "database", "table", "invalid endpoints", "format", "maxRetryTimes"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %s", err.Error(), want)
+		}
+	}
+}
+
+func TestValidateEndpoints(t *testing.T) {
+	testCases := []struct {
+		name      string
+		endpoints []string
+		wantErr   []string
+	}{
+		{
+			name:      "well-formed endpoints",
+			endpoints: []string{"http://127.0.0.1:8030", "https://fe.example.com:8030", "http://[::1]:8030"},
+		},
+		{
+			name:      "empty list",
+			endpoints: nil,
+			wantErr:   []string{"endpoints cannot be empty"},
+		},
+		{
+			name:      "missing scheme",
+			endpoints: []string{"http://127.0.0.1:8030", "10.16.10.6:8630", "localhost:8030"},
+			wantErr:   []string{`"10.16.10.6:8630"`, `"localhost:8030"`},
+		},
+		{
+			name:      "missing host",
+			endpoints: []string{"http://", "http:///api"},
+			wantErr:   []string{`"http://"`, `"http:///api"`},
+		},
+		{
+			name:      "unsupported scheme",
+			endpoints: []string{"https://127.0.0.1:8030", "ftp://127.0.0.1:8030", "file://fe/etc/hosts", "tcp://127.0.0.1:9030"},
+			wantErr:   []string{`"ftp://127.0.0.1:8030"`, `"file://fe/etc/hosts"`, `"tcp://127.0.0.1:9030"`},
+		},
+		{
+			name:      "upper case scheme",
+			endpoints: []string{"HTTP://127.0.0.1:8030"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			cfg.Endpoints = tc.endpoints
+			err := cfg.ValidateInternal()
+			if len(tc.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %v", tc.wantErr)
+			}
+			for _, want := range tc.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q should contain %s", err.Error(), want)
+				}
+			}
+			if strings.Contains(err.Error(), `"http://127.0.0.1:8030"`) {
+				t.Errorf("error should not list well-formed endpoints: %v", err)
+			}
+		})
+	}
+}
+
+func TestPickEndpoint(t *testing.T) {
+	const picks = 30000
+	testCases := []struct {
+		name     string
+		weights  map[string]int
+		expected map[string]float64
+	}{
+		{
+			name:     "equal weights",
+			expected: map[string]float64{"http://fe1:8030": 1.0 / 3, "http://fe2:8030": 1.0 / 3, "http://fe3:8030": 1.0 / 3},
+		},
+		{
+			name:     "weighted",
+			weights:  map[string]int{"http://fe1:8030": 6, "http://fe2:8030": 3},
+			expected: map[string]float64{"http://fe1:8030": 0.6, "http://fe2:8030": 0.3, "http://fe3:8030": 0.1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			cfg.Endpoints = []string{"http://fe1:8030", "http://fe2:8030", "http://fe3:8030"}
+			cfg.EndpointWeights = tc.weights
+			counts := make(map[string]int)
+			for i := 0; i < picks; i++ {
+				counts[cfg.PickEndpoint(cfg.Endpoints)]++
+			}
+			for endpoint, share := range tc.expected {
+				if got := float64(counts[endpoint]) / picks; math.Abs(got-share) > 0.02 {
+					t.Errorf("expected %s to be picked %.2f of the time, got %.3f", endpoint, share, got)
+				}
+			}
+		})
+	}
+}
+
+func TestStrictLabelPolicy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		strict      bool
+		groupCommit GroupCommitMode
+		options     map[string]string
+		label       string
+		labelPrefix string
+		wantErr     bool
+	}{
+		{name: "strict with label under group commit", strict: true, groupCommit: ASYNC, label: "l1", wantErr: true},
+		{name: "strict with prefix under group commit", strict: true, groupCommit: SYNC, labelPrefix: "p", wantErr: true},
+		{name: "strict with group commit option", strict: true, groupCommit: OFF, options: map[string]string{"group_commit": "async_mode"}, label: "l1", wantErr: true},
+		{name: "strict without group commit", strict: true, groupCommit: OFF, label: "l1"},
+		{name: "strict group commit without label", strict: true, groupCommit: ASYNC},
+		{name: "lenient with label under group commit", strict: false, groupCommit: ASYNC, label: "l1", labelPrefix: "p"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			cfg.StrictLabelPolicy = tc.strict
+			cfg.GroupCommit = tc.groupCommit
+			cfg.Options = tc.options
+			cfg.Label = tc.label
+			cfg.LabelPrefix = tc.labelPrefix
+			err := cfg.ValidateInternal()
+			if tc.wantErr != (err != nil) {
+				t.Errorf("expected error: %t, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestResolveEnv(t *testing.T) {
+	t.Setenv("DORIS_TEST_PASSWORD", "s3cret")
+	t.Setenv("DORIS_TEST_EMPTY", "")
+
+	testCases := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  string
+	}{
+		{name: "literal", value: "root", expected: "root"},
+		{name: "empty", value: "", expected: ""},
+		{name: "environment variable", value: "${ENV:DORIS_TEST_PASSWORD}", expected: "s3cret"},
+		{name: "empty environment variable", value: "${ENV:DORIS_TEST_EMPTY}", expected: ""},
+		{name: "not a whole reference", value: "x${ENV:DORIS_TEST_PASSWORD}", expected: "x${ENV:DORIS_TEST_PASSWORD}"},
+		{name: "missing environment variable", value: "${ENV:DORIS_TEST_MISSING}", wantErr: "environment variable DORIS_TEST_MISSING is not set"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ResolveEnv(tc.value)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetAllowedFailures(t *testing.T) {
+	testCases := []struct {
+		name     string
+		total    int
+		allowed  int
+		expected string
+		wantErr  string
+	}{
+		{name: "ratio", total: 1000, allowed: 5, expected: "0.005"},
+		{name: "repeating ratio", total: 3, allowed: 1, expected: "0.3333333333333333"},
+		{name: "no failures allowed", total: 100, allowed: 0, expected: "0"},
+		{name: "all rows allowed", total: 10, allowed: 10, expected: "1"},
+		{name: "more failures than rows", total: 10, allowed: 50, expected: "1"},
+		{name: "zero total", total: 0, allowed: 0, wantErr: "total rows must be positive to compute max_filter_ratio, got 0"},
+		{name: "negative total", total: -1, allowed: 0, wantErr: "total rows must be positive to compute max_filter_ratio, got -1"},
+		{name: "negative allowed", total: 10, allowed: -1, wantErr: "allowed failures cannot be negative, got -1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shared := map[string]string{"strict_mode": "true"}
+			cfg := newValidConfig()
+			cfg.Options = shared
+			err := cfg.SetAllowedFailures(tc.total, tc.allowed)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				if _, ok := cfg.Options["max_filter_ratio"]; ok {
+					t.Errorf("expected max_filter_ratio to be unset on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := cfg.Options["max_filter_ratio"]; got != tc.expected {
+				t.Errorf("expected max_filter_ratio %q, got %q", tc.expected, got)
+			}
+			if cfg.Options["strict_mode"] != "true" {
+				t.Errorf("expected the other options to be kept, got %v", cfg.Options)
+			}
+			if _, ok := shared["max_filter_ratio"]; ok {
+				t.Errorf("expected the options map given to the configuration to be left unchanged")
+			}
+		})
+	}
+}
+
+func TestJSONFormatOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   *JSONFormat
+		expected map[string]string
+	}{
+		{
+			name:     "object line",
+			format:   &JSONFormat{Type: JSONObjectLine},
+			expected: map[string]string{"format": "json", "read_json_by_line": "true"},
+		},
+		{
+			name:     "array",
+			format:   &JSONFormat{Type: JSONArray},
+			expected: map[string]string{"format": "json", "strip_outer_array": "true", "read_json_by_line": "false"},
+		},
+		{
+			name:     "object line with columns",
+			format:   &JSONFormat{Type: JSONObjectLine, Columns: []string{"ts", "message"}},
+			expected: map[string]string{"format": "json", "read_json_by_line": "true", "columns": "ts,message"},
+		},
+		{
+			name:     "auto column excluded from columns",
+			format:   &JSONFormat{Type: JSONObjectLine, Columns: []string{"id", "ts", "message"}, AutoColumns: []string{"ID"}},
+			expected: map[string]string{"format": "json", "read_json_by_line": "true", "columns": "ts,message"},
+		},
+		{
+			name:     "num as string",
+			format:   &JSONFormat{Type: JSONArray, NumAsString: true},
+			expected: map[string]string{"format": "json", "strip_outer_array": "true", "read_json_by_line": "false", "num_as_string": "true"},
+		},
+		{
+			name:     "auto column without columns",
+			format:   &JSONFormat{Type: JSONObjectLine, AutoColumns: []string{"id"}},
+			expected: map[string]string{"format": "json", "read_json_by_line": "true"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if options := tc.format.GetOptions(); !reflect.DeepEqual(options, tc.expected) {
+				t.Errorf("expected options %v, got %v", tc.expected, options)
+			}
+		})
+	}
+}
+
+// TestJSONFormatNumAsString shows the precision a large ID loses when parsed as a double, which num_as_string avoids
+func TestJSONFormatNumAsString(t *testing.T) {
+	record := []byte(`{"id":9007199254740993}`)
+	var asDouble map[string]float64
+	if err := json.Unmarshal(record, &asDouble); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strconv.FormatFloat(asDouble["id"], 'f', -1, 64); got == "9007199254740993" {
+		t.Fatalf("expected the double to lose precision, got %s", got)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(record))
+	decoder.UseNumber()
+	var asString map[string]json.Number
+	if err := decoder.Decode(&asString); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if asString["id"].String() != "9007199254740993" {
+		t.Errorf("expected the number read as a string to keep its digits, got %s", asString["id"])
+	}
+
+	if got := (&JSONFormat{Type: JSONObjectLine, NumAsString: true}).GetOptions()["num_as_string"]; got != "true" {
+		t.Errorf("expected num_as_string true, got %q", got)
+	}
+	if _, ok := (&JSONFormat{Type: JSONObjectLine}).GetOptions()["num_as_string"]; ok {
+		t.Errorf("num_as_string should not be set without NumAsString")
+	}
+}
+
+func TestCSVFormatOptions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		format          *CSVFormat
+		columnSeparator string
+		lineDelimiter   string
+	}{
+		{name: "newline", format: &CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"}, columnSeparator: ",", lineDelimiter: `\n`},
+		{name: "tab", format: &CSVFormat{ColumnSeparator: "\t", LineDelimiter: "\n"}, columnSeparator: `\t`, lineDelimiter: `\n`},
+		{name: "crlf", format: &CSVFormat{ColumnSeparator: "|", LineDelimiter: "\r\n"}, columnSeparator: "|", lineDelimiter: `\r\n`},
+		{name: "control character", format: &CSVFormat{ColumnSeparator: "\x01", LineDelimiter: "\x02"}, columnSeparator: `\x01`, lineDelimiter: `\x02`},
+		{name: "multiple characters", format: &CSVFormat{ColumnSeparator: "||", LineDelimiter: "$$\n"}, columnSeparator: "||", lineDelimiter: `$$\n`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.format.Validate(); err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+			options := tc.format.GetOptions()
+			if options["column_separator"] != tc.columnSeparator {
+				t.Errorf("expected column_separator %q, got %q", tc.columnSeparator, options["column_separator"])
+			}
+			if options["line_delimiter"] != tc.lineDelimiter {
+				t.Errorf("expected line_delimiter %q, got %q", tc.lineDelimiter, options["line_delimiter"])
+			}
+		})
+	}
+}
+
+func TestCSVFormatEnclose(t *testing.T) {
+	if _, ok := (&CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"}).GetOptions()["enclose"]; ok {
+		t.Errorf("expected no enclose header without Enclose")
+	}
+	format := &CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n", Enclose: '"'}
+	if err := format.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	if enclose := format.GetOptions()["enclose"]; enclose != `"` {
+		t.Errorf("expected enclose %q, got %q", `"`, enclose)
+	}
+}
+
+func TestCSVFormatValidation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		format  *CSVFormat
+		wantErr string
+	}{
+		{name: "literal escape", format: &CSVFormat{ColumnSeparator: ",", LineDelimiter: `\n`}, wantErr: "backslash"},
+		{name: "empty column separator", format: &CSVFormat{LineDelimiter: "\n"}, wantErr: "cannot be empty"},
+		{name: "empty line delimiter", format: &CSVFormat{ColumnSeparator: ","}, wantErr: "cannot be empty"},
+		{name: "same separators", format: &CSVFormat{ColumnSeparator: "\n", LineDelimiter: "\n"}, wantErr: "overlap"},
+		{name: "overlapping separators", format: &CSVFormat{ColumnSeparator: "\n", LineDelimiter: "\r\n"}, wantErr: "overlap"},
+		{name: "enclose in separator", format: &CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n", Enclose: ','}, wantErr: "enclose"},
+		{name: "backslash enclose", format: &CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n", Enclose: '\\'}, wantErr: "enclose"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			cfg.Format = tc.format
+			err := cfg.ValidateInternal()
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFormatValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		format  Format
+		wantErr string
+	}{
+		{name: "json object line", format: &JSONFormat{Type: JSONObjectLine}},
+		{name: "json array", format: &JSONFormat{Type: JSONArray, Columns: []string{"a", "b"}}},
+		{name: "csv", format: &CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"}},
+		{name: "json without type", format: &JSONFormat{}, wantErr: "json type must be"},
+		{name: "unknown json type", format: &JSONFormat{Type: "lines"}, wantErr: "json type must be"},
+		{name: "json auto columns", format: &JSONFormat{Type: JSONObjectLine, Columns: []string{"id", "a"}, AutoColumns: []string{"id"}}},
+		{name: "empty json auto column", format: &JSONFormat{Type: JSONObjectLine, AutoColumns: []string{" "}}, wantErr: "json auto columns cannot be empty"},
+		{name: "only json auto columns", format: &JSONFormat{Type: JSONObjectLine, Columns: []string{"id"}, AutoColumns: []string{"id"}}, wantErr: "cannot all be auto columns"},
+		{name: "empty column separator", format: &CSVFormat{LineDelimiter: "\n"}, wantErr: "cannot be empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.format.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected validation error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestJSONFormatColumns(t *testing.T) {
+	format := &JSONFormat{Type: JSONObjectLine, Columns: []string{"ts", "host", "message"}}
+	if err := format.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	if got := format.GetOptions()["columns"]; got != "ts,host,message" {
+		t.Errorf("expected columns ts,host,message, got %q", got)
+	}
+	if _, ok := (&JSONFormat{Type: JSONObjectLine}).GetOptions()["columns"]; ok {
+		t.Errorf("columns should not be set without Columns")
+	}
+
+	for _, columns := range [][]string{{"a", ""}, {"a,b"}} {
+		if err := (&JSONFormat{Type: JSONObjectLine, Columns: columns}).Validate(); err == nil {
+			t.Errorf("expected validation error for columns %q", columns)
+		}
+	}
+}
+
+func TestJSONColumns(t *testing.T) {
+	columns, err := JSONColumns([]byte(`{"ts": 1, "host": {"name": "a", "ip": "b"}, "tags": ["x"], "message": "m,n"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(columns, ",") != "ts,host,tags,message" {
+		t.Errorf("expected keys in order, got %v", columns)
+	}
+
+	for _, record := range []string{`[1, 2]`, `{"a": 1`, ``} {
+		if _, err := JSONColumns([]byte(record)); err == nil {
+			t.Errorf("expected error for %q", record)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"fmt"
+)
+
+// RetryBuilder builds a Retry configuration with a fluent API
+// Usage: NewRetryBuilder().WithMaxTimes(3).WithBaseInterval(500).WithMaxTotal(10000).WithJitter(true).Build()
+type RetryBuilder struct {
+	retry Retry
+}
+
+// NewRetryBuilder creates a builder starting from the default retry values (6 retries, 1 second base interval, 60s total)
+func NewRetryBuilder() *RetryBuilder {
+	return &RetryBuilder{
+		retry: Retry{
+			MaxRetryTimes:  6,
+			BaseIntervalMs: 1000,
+			MaxTotalTimeMs: 60000,
+		},
+	}
+}
+
+// WithMaxTimes sets the maximum number of retry attempts, 0 disables retry
+func (b *RetryBuilder) WithMaxTimes(maxRetryTimes int) *RetryBuilder {
+	b.retry.MaxRetryTimes = maxRetryTimes
+	return b
+}
+
+// WithBaseInterval sets the base interval in milliseconds for exponential backoff
+func (b *RetryBuilder) WithBaseInterval(baseIntervalMs int64) *RetryBuilder {
+	b.retry.BaseIntervalMs = baseIntervalMs
+	return b
+}
+
+// WithMaxTotal sets the maximum total time in milliseconds for all retries
+func (b *RetryBuilder) WithMaxTotal(maxTotalTimeMs int64) *RetryBuilder {
+	b.retry.MaxTotalTimeMs = maxTotalTimeMs
+	return b
+}
+
+// WithJitter enables or disables random jitter on the backoff intervals
+func (b *RetryBuilder) WithJitter(jitter bool) *RetryBuilder {
+	b.retry.Jitter = jitter
+	return b
+}
+
+// WithMaxInterval caps a single backoff interval in milliseconds, 0 leaves the intervals uncapped
+func (b *RetryBuilder) WithMaxInterval(maxIntervalMs int64) *RetryBuilder {
+	b.retry.MaxIntervalMs = maxIntervalMs
+	return b
+}
+
+// Build validates the values and returns a new Retry configuration
+func (b *RetryBuilder) Build() (*Retry, error) {
+	if b.retry.MaxRetryTimes < 0 {
+		return nil, fmt.Errorf("maxRetryTimes cannot be negative")
+	}
+	if b.retry.BaseIntervalMs <= 0 {
+		return nil, fmt.Errorf("baseIntervalMs must be positive")
+	}
+	if b.retry.MaxTotalTimeMs <= 0 {
+		return nil, fmt.Errorf("maxTotalTimeMs must be positive")
+	}
+	if b.retry.MaxIntervalMs < 0 {
+		return nil, fmt.Errorf("maxIntervalMs cannot be negative")
+	}
+	if b.retry.BaseIntervalMs > b.retry.MaxTotalTimeMs {
+		return nil, fmt.Errorf("baseIntervalMs (%d) cannot be greater than maxTotalTimeMs (%d)",
+			b.retry.BaseIntervalMs, b.retry.MaxTotalTimeMs)
+	}
+
+	retry := b.retry
+	return &retry, nil
+}
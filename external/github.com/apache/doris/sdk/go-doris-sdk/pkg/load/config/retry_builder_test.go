@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"testing"
+)
+
+func TestRetryBuilderValues(t *testing.T) {
+	retry, err := NewRetryBuilder().WithMaxTimes(3).WithBaseInterval(500).WithMaxTotal(10000).WithMaxInterval(2000).WithJitter(true).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Retry{MaxRetryTimes: 3, BaseIntervalMs: 500, MaxTotalTimeMs: 10000, MaxIntervalMs: 2000, Jitter: true}
+	if *retry != expected {
+		t.Errorf("expected %+v, got %+v", expected, *retry)
+	}
+}
+
+func TestRetryBuilderDefaults(t *testing.T) {
+	retry, err := NewRetryBuilder().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Retry{MaxRetryTimes: 6, BaseIntervalMs: 1000, MaxTotalTimeMs: 60000}
+	if *retry != expected {
+		t.Errorf("expected %+v, got %+v", expected, *retry)
+	}
+}
+
+func TestRetryBuilderBuildsIndependentValues(t *testing.T) {
+	builder := NewRetryBuilder().WithMaxTimes(1)
+	first, _ := builder.Build()
+	second, _ := builder.WithMaxTimes(2).Build()
+	if first.MaxRetryTimes != 1 || second.MaxRetryTimes != 2 {
+		t.Errorf("built values should not share state, got %d and %d", first.MaxRetryTimes, second.MaxRetryTimes)
+	}
+}
+
+func TestRetryBuilderValidation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *RetryBuilder
+	}{
+		{name: "negative max times", builder: NewRetryBuilder().WithMaxTimes(-1)},
+		{name: "zero base interval", builder: NewRetryBuilder().WithBaseInterval(0)},
+		{name: "negative base interval", builder: NewRetryBuilder().WithBaseInterval(-100)},
+		{name: "zero max total", builder: NewRetryBuilder().WithMaxTotal(0)},
+		{name: "base greater than total", builder: NewRetryBuilder().WithBaseInterval(5000).WithMaxTotal(1000)},
+		{name: "negative max interval", builder: NewRetryBuilder().WithMaxInterval(-1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			retry, err := tc.builder.Build()
+			if err == nil {
+				t.Errorf("expected validation error, got %+v", retry)
+			}
+		})
+	}
+
+	if _, err := NewRetryBuilder().WithMaxTimes(0).Build(); err != nil {
+		t.Errorf("zero max times should disable retry without error: %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package exception provides error types used in the Doris Stream Load client
+package exception
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StreamLoadError represents an error that occurred during a stream load operation
+type StreamLoadError struct {
+	Message string
+}
+
+// Error returns the error message
+func (e *StreamLoadError) Error() string {
+	return e.Message
+}
+
+// NewStreamLoadError creates a new StreamLoadError with the given message
+func NewStreamLoadError(message string) *StreamLoadError {
+	return &StreamLoadError{
+		Message: message,
+	}
+}
+
+// AuthError indicates that the load was rejected because of invalid credentials or missing privileges
+type AuthError struct {
+	*StreamLoadError
+}
+
+// NewAuthError creates a new AuthError with the given message
+func NewAuthError(message string) *AuthError {
+	return &AuthError{StreamLoadError: NewStreamLoadError(message)}
+}
+
+// Unwrap returns the base StreamLoadError
+func (e *AuthError) Unwrap() error {
+	return e.StreamLoadError
+}
+
+// ConnectionError indicates that the load request could not reach Doris or the connection was broken
+type ConnectionError struct {
+	*StreamLoadError
+	Cause error
+	// BodySent tells a read error from a write error: the whole request body was sent before the connection broke,
+	// so Doris may have committed the load
+	BodySent bool
+}
+
+// NewConnectionError creates a new ConnectionError with the given message and underlying network error
+func NewConnectionError(message string, cause error) *ConnectionError {
+	return &ConnectionError{StreamLoadError: NewStreamLoadError(message), Cause: cause}
+}
+
+// Unwrap returns the base StreamLoadError and the underlying network error
+func (e *ConnectionError) Unwrap() []error {
+	return []error{e.StreamLoadError, e.Cause}
+}
+
+// OutcomeUnknownError indicates that whether Doris committed the load could not be determined, e.g. the connection
+// broke after the whole data was sent or the job holding the label is still running, so the load is not retried
+// to avoid loading the data twice
+type OutcomeUnknownError struct {
+	*StreamLoadError
+	// Label of the load, empty under group commit. Check its state in Doris before loading the data again
+	Label string
+	Cause error
+}
+
+// NewOutcomeUnknownError creates a new OutcomeUnknownError with the given message, label and cause
+func NewOutcomeUnknownError(message string, label string, cause error) *OutcomeUnknownError {
+	return &OutcomeUnknownError{StreamLoadError: NewStreamLoadError(message), Label: label, Cause: cause}
+}
+
+// Unwrap returns the base StreamLoadError and the cause
+func (e *OutcomeUnknownError) Unwrap() []error {
+	return []error{e.StreamLoadError, e.Cause}
+}
+
+// DataQualityError indicates that Doris rejected the data, e.g. too many filtered rows
+type DataQualityError struct {
+	*StreamLoadError
+}
+
+// NewDataQualityError creates a new DataQualityError with the given message
+func NewDataQualityError(message string) *DataQualityError {
+	return &DataQualityError{StreamLoadError: NewStreamLoadError(message)}
+}
+
+// Unwrap returns the base StreamLoadError
+func (e *DataQualityError) Unwrap() error {
+	return e.StreamLoadError
+}
+
+// LabelExistsError indicates that a load job with the same label has already been submitted
+type LabelExistsError struct {
+	*StreamLoadError
+	// ExistingJobStatus is the status of the previous job with the same label
+	ExistingJobStatus string
+}
+
+// NewLabelExistsError creates a new LabelExistsError with the given message and status of the existing job
+func NewLabelExistsError(message string, existingJobStatus string) *LabelExistsError {
+	return &LabelExistsError{StreamLoadError: NewStreamLoadError(message), ExistingJobStatus: existingJobStatus}
+}
+
+// Unwrap returns the base StreamLoadError
+func (e *LabelExistsError) Unwrap() error {
+	return e.StreamLoadError
+}
+
+// EndpointError is the error of the last attempt of a load sent to an endpoint
+type EndpointError struct {
+	Endpoint string
+	Err      error
+}
+
+// Error returns the endpoint and its error
+func (e *EndpointError) Error() string {
+	return e.Endpoint + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error of the endpoint
+func (e *EndpointError) Unwrap() error {
+	return e.Err
+}
+
+// AllEndpointsFailedError indicates that a load was tried on every configured endpoint and failed on all of them
+type AllEndpointsFailedError struct {
+	*StreamLoadError
+	// Endpoints holds the error of each endpoint, in the order they were tried
+	Endpoints []*EndpointError
+}
+
+// NewAllEndpointsFailedError creates a new AllEndpointsFailedError listing each endpoint and its error
+func NewAllEndpointsFailedError(endpoints []*EndpointError) *AllEndpointsFailedError {
+	failures := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		failures = append(failures, endpoint.Error())
+	}
+	message := fmt.Sprintf("load failed on all %d endpoints: %s", len(endpoints), strings.Join(failures, "; "))
+	return &AllEndpointsFailedError{StreamLoadError: NewStreamLoadError(message), Endpoints: endpoints}
+}
+
+// Errors returns the error of each endpoint
+func (e *AllEndpointsFailedError) Errors() []error {
+	errs := make([]error, 0, len(e.Endpoints))
+	for _, endpoint := range e.Endpoints {
+		errs = append(errs, endpoint)
+	}
+	return errs
+}
+
+// Unwrap returns the base StreamLoadError and the error of each endpoint
+func (e *AllEndpointsFailedError) Unwrap() []error {
+	return append([]error{e.StreamLoadError}, e.Errors()...)
+}
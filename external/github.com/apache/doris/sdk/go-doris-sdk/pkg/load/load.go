@@ -0,0 +1,433 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package load provides the Doris Stream Load client functionality
+package load
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/client"
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/exception"
+	loader "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/loader"
+	log "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/logger"
+)
+
+// ================================
+// Public API Types
+// ================================
+
+// Config is the main configuration structure for stream load operations
+type Config = config.Config
+
+// DorisLoadClient provides functionality to load data into Doris using stream load API
+type DorisLoadClient = client.DorisLoadClient
+
+// ReaderJoin describes how LoadMulti joins several readers into one load
+type ReaderJoin = client.ReaderJoin
+
+// Format aliases
+type Format = config.Format
+type JSONFormatType = config.JSONFormatType
+type JSONFormat = config.JSONFormat
+type CSVFormat = config.CSVFormat
+
+// Config aliases (for backward compatibility)
+type LoadSetting = config.Config
+type BatchMode = config.GroupCommitMode
+type GroupCommitMode = config.GroupCommitMode
+type Retry = config.Retry
+type RetryBuilder = config.RetryBuilder
+type CSVFormatBuilder = config.CSVFormatBuilder
+type ValidationError = config.ValidationError
+type LoadTrace = config.LoadTrace
+type ConnectionPool = config.ConnectionPool
+type CircuitBreakerConfig = config.CircuitBreakerConfig
+type CircuitState = config.CircuitState
+
+// Log aliases
+type LogLevel = log.Level
+type LogFormat = log.Format
+type LogFunc = log.LogFunc
+type Logger = log.Logger
+type ContextLogger = log.ContextLogger
+
+// Load aliases
+type LoadResponse = loader.LoadResponse
+type LoadStatus = loader.LoadStatus
+type RespContent = loader.RespContent
+type ColumnInfo = loader.ColumnInfo
+type LoadStats = loader.LoadStats
+type LoadStatsSnapshot = loader.LoadStatsSnapshot
+
+// Error aliases, use errors.As to check the category of a load failure
+type StreamLoadError = exception.StreamLoadError
+type AuthError = exception.AuthError
+type ConnectionError = exception.ConnectionError
+type DataQualityError = exception.DataQualityError
+type LabelExistsError = exception.LabelExistsError
+type OutcomeUnknownError = exception.OutcomeUnknownError
+type AllEndpointsFailedError = exception.AllEndpointsFailedError
+type EndpointError = exception.EndpointError
+
+// ================================
+// Constants
+// ================================
+
+const (
+	// JSON format constants
+	JSONObjectLine = config.JSONObjectLine
+	JSONArray      = config.JSONArray
+
+	// Batch mode constants
+	SYNC  = config.SYNC
+	ASYNC = config.ASYNC
+	OFF   = config.OFF
+
+	// Load status constants
+	SUCCESS = loader.SUCCESS
+	FAILURE = loader.FAILURE
+
+	// Log level constants
+	LogLevelDebug = log.LevelDebug
+	LogLevelInfo  = log.LevelInfo
+	LogLevelWarn  = log.LevelWarn
+	LogLevelError = log.LevelError
+
+	// Log format constants
+	LogFormatText = log.FormatText
+	LogFormatJSON = log.FormatJSON
+
+	// Circuit breaker state constants
+	CircuitClosed   = config.CircuitClosed
+	CircuitOpen     = config.CircuitOpen
+	CircuitHalfOpen = config.CircuitHalfOpen
+
+	// Default size up to which load data is held in memory
+	DefaultMaxBufferBytes = config.DefaultMaxBufferBytes
+
+	// Default largest response body read from Doris
+	DefaultMaxResponseBytes = config.DefaultMaxResponseBytes
+
+	// Longest label Doris accepts
+	DefaultMaxLabelLength = config.DefaultMaxLabelLength
+
+	// Hidden column of unique key tables marking the rows to delete
+	DeleteSignColumn = config.DeleteSignColumn
+
+	// SDK version and the User-Agent header sent by default
+	Version          = config.Version
+	DefaultUserAgent = config.DefaultUserAgent
+)
+
+// ErrResponseTooLarge is returned when a Doris response exceeds MaxResponseBytes
+var ErrResponseTooLarge = loader.ErrResponseTooLarge
+
+// ================================
+// Client Creation Functions
+// ================================
+
+// NewLoadClient creates a new Doris stream load client with the given configuration
+func NewLoadClient(cfg *Config) (*DorisLoadClient, error) {
+	return client.NewDorisClient(cfg)
+}
+
+// WithExpectedRows returns a context making the loads run with it warn when Doris counts materially more or fewer
+// rows than the given number
+func WithExpectedRows(ctx context.Context, rows int64) context.Context {
+	return client.WithExpectedRows(ctx, rows)
+}
+
+// SetGlobalConcurrencyLimit caps the stream load requests in flight across all clients of the process, zero means unlimited
+func SetGlobalConcurrencyLimit(n int) {
+	client.SetGlobalConcurrencyLimit(n)
+}
+
+// ================================
+// Retry Configuration
+// ================================
+
+// NewRetry creates a new retry configuration
+func NewRetry(maxRetryTimes int, baseIntervalMs int64) *Retry {
+	return &Retry{
+		MaxRetryTimes:  maxRetryTimes,
+		BaseIntervalMs: baseIntervalMs,
+		MaxTotalTimeMs: 60000, // Default 60 seconds total
+	}
+}
+
+// DefaultRetry creates a new retry configuration with default values (6 retries, 1 second base interval, 60s total)
+// Uses exponential backoff: 1s, 2s, 4s, 8s, 16s, 32s = ~63 seconds total retry time
+func DefaultRetry() *Retry {
+	return &Retry{
+		MaxRetryTimes:  6,     // Maximum 6 retries
+		BaseIntervalMs: 1000,  // 1 second base interval
+		MaxTotalTimeMs: 60000, // 60 seconds total limit
+	}
+}
+
+// NewRetryBuilder creates a fluent retry builder starting from the default retry values
+// Usage: NewRetryBuilder().WithMaxTimes(3).WithBaseInterval(500).WithMaxTotal(10000).WithJitter(true).Build()
+func NewRetryBuilder() *RetryBuilder {
+	return config.NewRetryBuilder()
+}
+
+// NewDefaultRetry creates a new retry configuration with default values (6 retries, 1 second base interval, 60s total)
+// Uses exponential backoff: 1s, 2s, 4s, 8s, 16s, 32s = ~63 seconds total retry time
+func NewDefaultRetry() *Retry {
+	return DefaultRetry()
+}
+
+// ================================
+// Format Configuration
+// ================================
+
+// DefaultJSONFormat creates a default JSON format configuration (JSONObjectLine)
+func DefaultJSONFormat() *JSONFormat {
+	return &JSONFormat{Type: JSONObjectLine}
+}
+
+// DefaultCSVFormat creates a default CSV format configuration (comma separator, newline delimiter)
+func DefaultCSVFormat() *CSVFormat {
+	return &CSVFormat{
+		ColumnSeparator: ",",
+		LineDelimiter:   "\n",
+	}
+}
+
+// NewCSVFormat creates a fluent CSV format builder starting from a comma separator and a newline delimiter
+// Usage: NewCSVFormat().WithColumnSeparator(",").WithLineDelimiter("\n").WithEnclose('"').Build()
+func NewCSVFormat() *CSVFormatBuilder {
+	return config.NewCSVFormat()
+}
+
+// ================================
+// Data Conversion Helpers
+// ================================
+
+// Data up to Config.MaxBufferBytes is buffered in memory by Load, larger readers implementing io.Seeker are
+// rewound on retries and other larger readers are streamed in a single attempt
+
+// StringReader converts string data to io.Reader, the returned reader is seekable
+func StringReader(data string) io.Reader {
+	return strings.NewReader(data)
+}
+
+// BytesReader converts byte data to io.Reader, the returned reader is seekable and does not copy the data
+func BytesReader(data []byte) io.Reader {
+	return bytes.NewReader(data)
+}
+
+// LineReader streams the lines received from the channel, each followed by a newline, until the channel is closed
+// The returned reader is not seekable, so Load can only retry it when its content fits in Config.MaxBufferBytes
+func LineReader(lines <-chan []byte) io.Reader {
+	return &lineReader{lines: lines}
+}
+
+// lineReader reads lines from a channel without buffering them
+type lineReader struct {
+	lines   <-chan []byte
+	current []byte
+	pending bool // whether the newline after the current line is still to be read
+}
+
+// Read implements io.Reader, it returns at most one line per call so that it never blocks with data at hand
+func (r *lineReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if len(r.current) == 0 && !r.pending {
+		line, ok := <-r.lines
+		if !ok {
+			return 0, io.EOF
+		}
+		r.current = line
+		r.pending = true
+	}
+
+	n := copy(p, r.current)
+	r.current = r.current[n:]
+	if len(r.current) == 0 && n < len(p) {
+		p[n] = '\n'
+		n++
+		r.pending = false
+	}
+	return n, nil
+}
+
+// JSONArrayReader streams the JSON records of a reader, one per line, as a single JSON array to be loaded with
+// the JSONArray format. The array is written while the records are read, so it is never held in memory as a whole
+// Blank lines are skipped, and the returned reader is not seekable
+func JSONArrayReader(records io.Reader) io.Reader {
+	return &jsonArrayReader{records: records}
+}
+
+// jsonArrayReader writes the opening bracket, the records separated by commas and the closing bracket
+type jsonArrayReader struct {
+	records  io.Reader
+	buf      []byte // Data read from the records
+	out      []byte // Encoded data, pending is its unread part
+	pending  []byte
+	err      error
+	started  bool
+	inRecord bool // Whether the current line holds a record
+	wrote    bool // Whether any record was written
+	done     bool
+}
+
+// Read implements io.Reader
+func (r *jsonArrayReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.out = r.out[:0]
+		if !r.started {
+			r.started = true
+			r.out = append(r.out, '[')
+		}
+		if cap(r.buf) < len(p) {
+			r.buf = make([]byte, len(p))
+		}
+		n, err := r.records.Read(r.buf[:len(p)])
+		r.encode(r.buf[:n])
+		if errors.Is(err, io.EOF) {
+			r.out = append(r.out, ']')
+			r.done = true
+		} else if err != nil {
+			r.err = err
+		}
+		r.pending = r.out
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// encode appends the data to the output, dropping line breaks and separating the records with commas
+func (r *jsonArrayReader) encode(data []byte) {
+	for _, b := range data {
+		switch {
+		case b == '\n':
+			r.inRecord = false
+			continue
+		case !r.inRecord && (b == ' ' || b == '\t' || b == '\r'):
+			continue
+		case !r.inRecord:
+			r.inRecord = true
+			if r.wrote {
+				r.out = append(r.out, ',')
+			}
+			r.wrote = true
+		}
+		r.out = append(r.out, b)
+	}
+}
+
+// JSONReader converts any JSON-serializable object to io.Reader, the returned reader is seekable
+func JSONReader(data interface{}) (io.Reader, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(string(jsonBytes)), nil
+}
+
+// JSONColumns returns the keys of a JSON object in order, to be used as JSONFormat.Columns
+func JSONColumns(record []byte) ([]string, error) {
+	return config.JSONColumns(record)
+}
+
+// ================================
+// Log Control Functions
+// ================================
+
+// SetLogLevel sets the minimum log level for the SDK
+// Available levels: LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError
+func SetLogLevel(level LogLevel) {
+	log.SetLevel(level)
+}
+
+// SetLogFormat sets the output format of the SDK logs
+// Available formats: LogFormatText (default), LogFormatJSON
+func SetLogFormat(format LogFormat) {
+	log.SetFormat(format)
+}
+
+// SetLogOutput sets the output destination for SDK logs
+func SetLogOutput(output *os.File) {
+	log.SetOutput(output)
+}
+
+// DisableLogging completely disables all SDK logging
+func DisableLogging() {
+	log.SetLevel(log.Level(999))
+}
+
+// SetCustomLogFunc allows users to integrate their own logging systems
+func SetCustomLogFunc(level LogLevel, fn LogFunc) {
+	switch level {
+	case log.LevelDebug:
+		log.SetDebugFunc(fn)
+	case log.LevelInfo:
+		log.SetInfoFunc(fn)
+	case log.LevelWarn:
+		log.SetWarnFunc(fn)
+	case log.LevelError:
+		log.SetErrorFunc(fn)
+	}
+}
+
+// SetCustomLogFuncs allows setting all log functions at once
+func SetCustomLogFuncs(debugFn, infoFn, warnFn, errorFn LogFunc) {
+	if debugFn != nil {
+		log.SetDebugFunc(debugFn)
+	}
+	if infoFn != nil {
+		log.SetInfoFunc(infoFn)
+	}
+	if warnFn != nil {
+		log.SetWarnFunc(warnFn)
+	}
+	if errorFn != nil {
+		log.SetErrorFunc(errorFn)
+	}
+}
+
+// SetLogger routes all SDK logs to the given application logger
+// Passing nil restores the default SDK logger
+func SetLogger(logger Logger) {
+	log.SetLogger(logger)
+}
+
+// NewContextLogger creates a context logger with the given context string
+func NewContextLogger(context string) *ContextLogger {
+	return log.NewContextLogger(context)
+}
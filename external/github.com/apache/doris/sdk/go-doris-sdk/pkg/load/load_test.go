@@ -0,0 +1,168 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestBytesReader(t *testing.T) {
+	data := []byte("1,Alice,25\n2,Bob,30\n")
+	reader := BytesReader(data)
+
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		t.Fatalf("BytesReader should implement io.Seeker")
+	}
+
+	for i := 0; i < 2; i++ {
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("failed to read: %v", err)
+		}
+		if string(content) != string(data) {
+			t.Errorf("expected %q, got %q", data, content)
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			t.Fatalf("failed to seek: %v", err)
+		}
+	}
+}
+
+func TestLineReader(t *testing.T) {
+	lines := make(chan []byte, 4)
+	lines <- []byte(`{"id":1}`)
+	lines <- []byte{}
+	lines <- []byte(`{"id":2,"name":"a longer line"}`)
+	close(lines)
+
+	reader := LineReader(lines)
+	if _, ok := reader.(io.Seeker); ok {
+		t.Errorf("LineReader should not be seekable")
+	}
+
+	// Read one byte at a time to check lines and newlines split across reads
+	content, err := io.ReadAll(iotest.OneByteReader(reader))
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+	expected := "{\"id\":1}\n\n{\"id\":2,\"name\":\"a longer line\"}\n"
+	if string(content) != expected {
+		t.Errorf("expected %q, got %q", expected, content)
+	}
+}
+
+func TestLineReaderDoesNotWaitWithDataAtHand(t *testing.T) {
+	lines := make(chan []byte, 1)
+	lines <- []byte("first")
+	reader := LineReader(lines)
+
+	// The channel stays open, so reading must return the first line without waiting for more
+	buf := make([]byte, 64)
+	n, err := reader.Read(buf)
+	if err != nil || string(buf[:n]) != "first\n" {
+		t.Errorf("expected %q, got %q, err: %v", "first\n", buf[:n], err)
+	}
+	close(lines)
+	if _, err := reader.Read(buf); err != io.EOF {
+		t.Errorf("expected EOF after the channel is closed, got %v", err)
+	}
+}
+
+func TestJSONArrayReader(t *testing.T) {
+	testCases := []struct {
+		name     string
+		records  string
+		expected string
+	}{
+		{name: "records", records: "{\"id\":1}\n{\"id\":2}\n", expected: `[{"id":1},{"id":2}]`},
+		{name: "no trailing newline", records: "{\"id\":1}\n{\"id\":2}", expected: `[{"id":1},{"id":2}]`},
+		{name: "blank lines", records: "\n{\"id\":1}\r\n  \n\t{\"id\":2}\n\n", expected: "[{\"id\":1}\r,{\"id\":2}]"},
+		{name: "empty", records: "", expected: "[]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Read one byte at a time to check the brackets and commas are written across reads
+			content, err := io.ReadAll(iotest.OneByteReader(JSONArrayReader(strings.NewReader(tc.records))))
+			if err != nil {
+				t.Fatalf("failed to read: %v", err)
+			}
+			if string(content) != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, content)
+			}
+			if !json.Valid(content) {
+				t.Errorf("expected valid JSON, got %q", content)
+			}
+		})
+	}
+}
+
+func TestJSONArrayReaderLargeInput(t *testing.T) {
+	const count = 200000
+	records, writer := io.Pipe()
+	go func() {
+		for i := 0; i < count; i++ {
+			fmt.Fprintf(writer, "{\"id\":%d,\"name\":\"record %d\"}\n", i, i)
+		}
+		writer.Close()
+	}()
+
+	// Decode the array as it is streamed, it is never held in memory as a whole
+	decoder := json.NewDecoder(JSONArrayReader(records))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		t.Fatalf("expected an opening bracket, got %v, %v", token, err)
+	}
+	i := 0
+	for ; decoder.More(); i++ {
+		var record struct{ ID int }
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatalf("failed to decode record %d: %v", i, err)
+		}
+		if record.ID != i {
+			t.Fatalf("expected record %d, got %d", i, record.ID)
+		}
+	}
+	if i != count {
+		t.Fatalf("expected %d records, got %d", count, i)
+	}
+	if token, err := decoder.Token(); err != nil || token != json.Delim(']') {
+		t.Fatalf("expected a closing bracket, got %v, %v", token, err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		t.Errorf("expected the end of the data, got %v", err)
+	}
+}
+
+func TestJSONArrayReaderError(t *testing.T) {
+	readErr := errors.New("read failed")
+	reader := JSONArrayReader(io.MultiReader(strings.NewReader("{\"id\":1}\n"), iotest.ErrReader(readErr)))
+	content, err := io.ReadAll(reader)
+	if !errors.Is(err, readErr) {
+		t.Fatalf("expected the read error, got %v", err)
+	}
+	if string(content) != `[{"id":1}` {
+		t.Errorf("expected the data read before the error, got %q", content)
+	}
+}
@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultErrorDetailsBytes is the amount of error details returned when no limit is given
+const DefaultErrorDetailsBytes int64 = 64 * 1024
+
+// gzipMagic is the header of gzip data, used to detect files served gzipped without a Content-Encoding
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// FetchErrorDetails downloads the rejected rows file at the ErrorURL of a load, up to maxBytes of it
+// Some deployments serve the file gzipped, it is decompressed when the response has Content-Encoding gzip
+// or starts with the gzip header. A maxBytes of zero or less uses DefaultErrorDetailsBytes
+func (s *StreamLoader) FetchErrorDetails(errorURL string, maxBytes int64) (string, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultErrorDetailsBytes
+	}
+
+	req, err := http.NewRequest(http.MethodGet, errorURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid error URL: %w", err)
+	}
+	// Requesting gzip explicitly disables the transparent decompression of the transport, so it is handled below
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch error details: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch error details: %s", resp.Status)
+	}
+
+	body, err := decodeErrorDetails(resp)
+	if err != nil {
+		return "", err
+	}
+	details, err := io.ReadAll(io.LimitReader(body, maxBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read error details: %w", err)
+	}
+	return string(details), nil
+}
+
+// decodeErrorDetails returns the decompressed body of an error details response
+func decodeErrorDetails(resp *http.Response) (io.Reader, error) {
+	var body io.Reader = resp.Body
+	isGzip := strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip")
+	if !isGzip {
+		// Peek at the first bytes without losing them
+		head := make([]byte, len(gzipMagic))
+		n, err := io.ReadFull(resp.Body, head)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return nil, fmt.Errorf("failed to read error details: %w", err)
+		}
+		body = io.MultiReader(bytes.NewReader(head[:n]), resp.Body)
+		isGzip = bytes.Equal(head[:n], gzipMagic)
+	}
+	if !isGzip {
+		return body, nil
+	}
+
+	gzipReader, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress error details: %w", err)
+	}
+	return gzipReader, nil
+}
@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const errorDetails = "Reason: column count mismatch, expect=3 real=2. src line [1,2];\n"
+
+// gzipData compresses data with gzip
+func gzipData(t *testing.T, data string) []byte {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(data)); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestFetchErrorDetails(t *testing.T) {
+	testCases := []struct {
+		name            string
+		body            []byte
+		contentEncoding string
+		maxBytes        int64
+		expected        string
+	}{
+		{name: "plain", body: []byte(errorDetails), expected: errorDetails},
+		{name: "gzip content encoding", body: gzipData(t, errorDetails), contentEncoding: "gzip", expected: errorDetails},
+		{name: "gzip without content encoding", body: gzipData(t, errorDetails), expected: errorDetails},
+		{name: "gzip truncated", body: gzipData(t, errorDetails), contentEncoding: "gzip", maxBytes: 7, expected: "Reason:"},
+		{name: "empty", body: nil, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.contentEncoding != "" {
+					w.Header().Set("Content-Encoding", tc.contentEncoding)
+				}
+				w.Write(tc.body)
+			}))
+			defer server.Close()
+
+			details, err := NewStreamLoader().FetchErrorDetails(server.URL+"/api/_load_error_log?file=error_log", tc.maxBytes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if details != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, details)
+			}
+		})
+	}
+}
+
+func TestFetchErrorDetailsHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := NewStreamLoader().FetchErrorDetails(server.URL, 0)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected 404 error, got %v", err)
+	}
+}
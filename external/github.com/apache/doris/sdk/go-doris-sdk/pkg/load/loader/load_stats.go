@@ -0,0 +1,86 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"sync"
+	"time"
+)
+
+// LoadStats accumulates the results of loads, it is safe for concurrent use and its zero value is ready to use
+type LoadStats struct {
+	mu            sync.Mutex
+	loads         int64
+	successes     int64
+	failures      int64
+	rows          int64
+	filteredRows  int64
+	bytes         int64
+	timedLoads    int64
+	totalLoadTime time.Duration
+}
+
+// LoadStatsSnapshot contains the totals of a LoadStats at a point in time
+type LoadStatsSnapshot struct {
+	Loads        int64         // Number of added responses
+	Successes    int64         // Number of successful loads
+	Failures     int64         // Number of failed loads, including nil responses
+	Rows         int64         // Loaded rows of successful loads
+	FilteredRows int64         // Filtered rows of successful loads
+	Bytes        int64         // Loaded bytes of successful loads
+	AvgLatency   time.Duration // Average server side load time of the loads that reported one
+}
+
+// Add accumulates a load response, a nil response counts as a failure
+func (s *LoadStats) Add(resp *LoadResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loads++
+	if resp == nil || resp.Status != SUCCESS {
+		s.failures++
+	} else {
+		s.successes++
+		s.rows += resp.Resp.NumberLoadedRows
+		s.filteredRows += int64(resp.Resp.NumberFilteredRows)
+		s.bytes += resp.Resp.LoadBytes
+	}
+	if resp != nil && resp.Resp.LoadTimeMs > 0 {
+		s.totalLoadTime += resp.Resp.TotalServerTime()
+		s.timedLoads++
+	}
+}
+
+// Snapshot returns the current totals
+func (s *LoadStats) Snapshot() LoadStatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := LoadStatsSnapshot{
+		Loads:        s.loads,
+		Successes:    s.successes,
+		Failures:     s.failures,
+		Rows:         s.rows,
+		FilteredRows: s.filteredRows,
+		Bytes:        s.bytes,
+	}
+	if s.timedLoads > 0 {
+		snapshot.AvgLatency = s.totalLoadTime / time.Duration(s.timedLoads)
+	}
+	return snapshot
+}
@@ -0,0 +1,78 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoadStatsConcurrentAdd(t *testing.T) {
+	const workers = 8
+	const loadsPerWorker = 1000
+
+	success := &LoadResponse{
+		Status: SUCCESS,
+		Resp:   RespContent{NumberLoadedRows: 10, NumberFilteredRows: 1, LoadBytes: 100, LoadTimeMs: 20},
+	}
+	failure := &LoadResponse{Status: FAILURE, Resp: RespContent{LoadTimeMs: 40}}
+
+	var stats LoadStats
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < loadsPerWorker; j++ {
+				switch j % 4 {
+				case 0:
+					stats.Add(failure)
+				case 1:
+					stats.Add(nil)
+				default:
+					stats.Add(success)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	snapshot := stats.Snapshot()
+	successes := int64(workers * loadsPerWorker / 2)
+	expected := LoadStatsSnapshot{
+		Loads:        workers * loadsPerWorker,
+		Successes:    successes,
+		Failures:     workers * loadsPerWorker / 2,
+		Rows:         successes * 10,
+		FilteredRows: successes,
+		Bytes:        successes * 100,
+		// Two successes of 20ms for each failure of 40ms, nil responses have no load time
+		AvgLatency: time.Duration(80) * time.Millisecond / 3,
+	}
+	if snapshot != expected {
+		t.Fatalf("expected %+v, got %+v", expected, snapshot)
+	}
+}
+
+func TestLoadStatsEmpty(t *testing.T) {
+	var stats LoadStats
+	if snapshot := stats.Snapshot(); snapshot != (LoadStatsSnapshot{}) {
+		t.Fatalf("expected empty snapshot, got %+v", snapshot)
+	}
+}
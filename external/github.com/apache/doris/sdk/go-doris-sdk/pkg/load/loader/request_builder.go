@@ -0,0 +1,375 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	log "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	StreamLoadPattern = "http://%s/api/%s/%s/_stream_load"
+
+	StreamLoad2PCPattern = "http://%s/api/%s/_stream_load_2pc"
+
+	LoadStatePattern = "http://%s/api/%s/get_load_state?label=%s"
+
+	SchemaPattern = "http://%s/api/%s/%s/_schema"
+
+	// WarehouseHeader and ClusterHeader select the SelectDB Cloud warehouse and compute cluster of a load
+	WarehouseHeader = "warehouse"
+	ClusterHeader   = "cloud_cluster"
+
+	// TraceIDHeader carries the trace ID of a load for distributed tracing
+	TraceIDHeader = "X-Request-Id"
+)
+
+// getNode randomly selects an endpoint according to the endpoint weights and returns its parsed URL
+func getNode(cfg *config.Config) (*url.URL, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints available")
+	}
+
+	endpoint := cfg.PickEndpoint(cfg.Endpoints)
+
+	// Parse the endpoint URL to extract the scheme and host
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint URL: %v", err)
+	}
+
+	return endpointURL, nil
+}
+
+// apiHost returns the host of an endpoint followed by the configured PathPrefix, which the URL patterns extend
+func apiHost(node *url.URL, cfg *config.Config) string {
+	return node.Host + cfg.PathPrefix
+}
+
+// newNodeRequest creates a request to a URL built from one of the http patterns,
+// switching it to https for https endpoints
+func newNodeRequest(method string, node *url.URL, rawURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if node.Scheme == "https" {
+		req.URL.Scheme = "https"
+	}
+	return req, nil
+}
+
+// CreateStreamLoadRequest creates an HTTP PUT request for Doris stream load
+func CreateStreamLoadRequest(cfg *config.Config, data io.Reader, attempt int) (*http.Request, error) {
+	return CreateStreamLoadRequestWithAuth(cfg, data, attempt, BasicAuthHeader(cfg.User, cfg.Password))
+}
+
+// CreateStreamLoadRequestWithAuth creates a stream load request with a precomputed Authorization header,
+// which must match the credentials of the configuration
+func CreateStreamLoadRequestWithAuth(cfg *config.Config, data io.Reader, attempt int, authorization string) (*http.Request, error) {
+	// Get a random endpoint
+	node, err := getNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Construct the load URL
+	loadURL := fmt.Sprintf(StreamLoadPattern, apiHost(node, cfg), cfg.Database, cfg.Table)
+
+	// Create the HTTP PUT request
+	req, err := newNodeRequest(http.MethodPut, node, loadURL, data)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = StreamLoadHeaders(cfg, authorization)
+
+	// Handle label generation based on group commit usage
+	handleLabelForRequest(cfg, req, attempt)
+
+	return req, nil
+}
+
+// StreamLoadHeaders returns the headers of the stream loads of a configuration with the given Authorization header,
+// except the label generated for each request
+func StreamLoadHeaders(cfg *config.Config, authorization string) http.Header {
+	header := make(http.Header)
+
+	// Add the extra headers first so that the headers set by the SDK take precedence
+	for key, value := range cfg.ExtraHeaders {
+		header.Set(key, value)
+	}
+
+	// Add basic authentication
+	header.Set("Authorization", authorization)
+
+	// Add common headers
+	header.Set("User-Agent", cfg.GetUserAgent())
+	header.Set("Expect", "100-continue")
+	if cfg.Format != nil {
+		header.Set("Content-Type", cfg.Format.ContentType())
+	}
+
+	// Build and add all stream load options as headers
+	for key, value := range buildStreamLoadOptions(cfg) {
+		header.Set(key, value)
+	}
+
+	// Add cloud warehouse and cluster headers
+	if cfg.Warehouse != "" {
+		header.Set(WarehouseHeader, cfg.Warehouse)
+	}
+	if cfg.Cluster != "" {
+		header.Set(ClusterHeader, cfg.Cluster)
+	}
+	return header
+}
+
+// CreateAbortTransactionRequest creates an HTTP PUT request aborting a pre-committed two-phase commit transaction
+func CreateAbortTransactionRequest(cfg *config.Config, txnID int64) (*http.Request, error) {
+	node, err := getNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := newNodeRequest(http.MethodPut, node, fmt.Sprintf(StreamLoad2PCPattern, apiHost(node, cfg), cfg.Database), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", BasicAuthHeader(cfg.User, cfg.Password))
+	req.Header.Set("User-Agent", cfg.GetUserAgent())
+	req.Header.Set("txn_id", strconv.FormatInt(txnID, 10))
+	req.Header.Set("txn_operation", "abort")
+	return req, nil
+}
+
+// CreateLoadStateRequest creates an HTTP GET request querying the state of the load with the given label
+func CreateLoadStateRequest(cfg *config.Config, label string) (*http.Request, error) {
+	node, err := getNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	stateURL := fmt.Sprintf(LoadStatePattern, apiHost(node, cfg), url.PathEscape(cfg.Database), url.QueryEscape(label))
+	req, err := newNodeRequest(http.MethodGet, node, stateURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", BasicAuthHeader(cfg.User, cfg.Password))
+	req.Header.Set("User-Agent", cfg.GetUserAgent())
+	return req, nil
+}
+
+// CreateSchemaRequest creates an HTTP GET request for the schema of the configured table
+func CreateSchemaRequest(cfg *config.Config) (*http.Request, error) {
+	node, err := getNode(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaURL := fmt.Sprintf(SchemaPattern, apiHost(node, cfg), url.PathEscape(cfg.Database), url.PathEscape(cfg.Table))
+	req, err := newNodeRequest(http.MethodGet, node, schemaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", BasicAuthHeader(cfg.User, cfg.Password))
+	req.Header.Set("User-Agent", cfg.GetUserAgent())
+	return req, nil
+}
+
+// BasicAuthHeader returns the Authorization header value of the credentials
+func BasicAuthHeader(user, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+}
+
+// handleLabelForRequest handles label generation and setting based on group commit configuration
+func handleLabelForRequest(cfg *config.Config, req *http.Request, attempt int) {
+	// Check if group commit is enabled
+	_, isGroupCommitEnabled := req.Header[http.CanonicalHeaderKey("group_commit")]
+
+	if isGroupCommitEnabled {
+		// Group commit is enabled, labels are not allowed
+		if cfg.Label != "" {
+			// User provided a custom label but group commit is enabled
+			log.Warnf("Custom label '%s' specified but group_commit is enabled. Labels are not allowed with group commit, removing label.", cfg.Label)
+		}
+		if cfg.LabelPrefix != "" {
+			// User provided a label prefix but group commit is enabled
+			log.Warnf("Label prefix '%s' specified but group_commit is enabled. Labels are not allowed with group commit, removing label prefix.", cfg.LabelPrefix)
+		}
+		// Log the removal action
+		log.Infof("Group commit is enabled - all labels removed from request headers for compliance")
+		// Do not set any label when group commit is enabled
+		return
+	}
+
+	// Group commit is not enabled, generate and set label
+	label := generateLabel(cfg, attempt)
+	req.Header.Set("label", label)
+
+	if attempt > 0 {
+		log.Debugf("Generated retry label for attempt %d: %s", attempt, label)
+	} else {
+		log.Debugf("Generated label: %s", label)
+	}
+}
+
+// buildStreamLoadOptions builds all stream load options from configuration
+func buildStreamLoadOptions(cfg *config.Config) map[string]string {
+	result := make(map[string]string)
+
+	// Add user-defined options first
+	for k, v := range cfg.Options {
+		result[k] = v
+	}
+
+	if cfg.LoadTimeoutSeconds != nil {
+		result["timeout"] = strconv.Itoa(*cfg.LoadTimeoutSeconds)
+	}
+	if cfg.Timezone != "" {
+		result["timezone"] = cfg.Timezone
+	}
+	if cfg.ExecMemLimitBytes != nil {
+		result["exec_mem_limit"] = strconv.FormatInt(*cfg.ExecMemLimitBytes, 10)
+	}
+
+	// Add format-specific options
+	if cfg.Format != nil {
+		for k, v := range cfg.Format.GetOptions() {
+			result[k] = v
+		}
+	}
+
+	if len(cfg.ConstantColumns) > 0 {
+		addConstantColumns(result, cfg.ConstantColumns)
+	}
+	if cfg.DeleteSign {
+		addDeleteSign(result)
+	}
+
+	// Add group commit options
+	switch cfg.GroupCommit {
+	case config.SYNC:
+		result["group_commit"] = "sync_mode"
+	case config.ASYNC:
+		result["group_commit"] = "async_mode"
+	case config.OFF:
+		// Don't add group_commit option
+	}
+
+	return result
+}
+
+// constantQuoter escapes a constant value inside a single quoted SQL string
+var constantQuoter = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// addConstantColumns appends an expression setting each constant column, in name order, to the columns list
+func addConstantColumns(options map[string]string, constants map[string]string) {
+	names := make([]string, 0, len(constants))
+	for name := range constants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	columns := []string{}
+	if existing := options["columns"]; existing != "" {
+		columns = append(columns, existing)
+	}
+	for _, name := range names {
+		columns = append(columns, name+"='"+constantQuoter.Replace(constants[name])+"'")
+	}
+	options["columns"] = strings.Join(columns, ",")
+}
+
+// addDeleteSign declares the delete sign column of the data: appended to an explicit columns list, which Doris
+// requires to list it, or sent as hidden_columns otherwise
+func addDeleteSign(options map[string]string) {
+	columns, ok := options["columns"]
+	if !ok {
+		options["hidden_columns"] = config.DeleteSignColumn
+		return
+	}
+	for _, column := range strings.Split(columns, ",") {
+		if strings.EqualFold(strings.TrimSpace(column), config.DeleteSignColumn) {
+			return
+		}
+	}
+	options["columns"] = columns + "," + config.DeleteSignColumn
+}
+
+// generateLabel creates a unique label for the load job, considering retry attempts
+func generateLabel(cfg *config.Config, attempt int) string {
+	currentTimeMillis := time.Now().UnixMilli()
+	id := uuid.New()
+
+	// If user provided a custom label, handle retry scenarios
+	if cfg.Label != "" {
+		if attempt == 0 {
+			// First attempt: use the original label
+			return cfg.Label
+		} else {
+			// Retry attempts: append retry suffix to ensure uniqueness
+			suffix := fmt.Sprintf("_retry_%d_%d_%s", attempt, currentTimeMillis, id.String()[:8])
+			return fitLabel(cfg.Label, suffix, cfg.GetMaxLabelLength())
+		}
+	}
+
+	// Generate a unique label when no custom label is provided
+	prefix := cfg.LabelPrefix
+	if prefix == "" {
+		prefix = "load"
+	}
+	head := fmt.Sprintf("%s_%s_%s", prefix, cfg.Database, cfg.Table)
+
+	if attempt == 0 {
+		// First attempt
+		return fitLabel(head, fmt.Sprintf("_%d_%s", currentTimeMillis, id.String()), cfg.GetMaxLabelLength())
+	} else {
+		// Retry attempts: include attempt number for uniqueness
+		return fitLabel(head, fmt.Sprintf("_%d_retry_%d_%s", currentTimeMillis, attempt, id.String()), cfg.GetMaxLabelLength())
+	}
+}
+
+// fitLabel replaces the characters Doris does not accept in the head of a label by '_', and truncates it
+// so that the label including the unique suffix is at most maxLength characters
+func fitLabel(head, suffix string, maxLength int) string {
+	head = strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == ':' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return '_'
+	}, head)
+	if room := maxLength - len(suffix); len(head) > room {
+		if room < 0 {
+			room = 0
+		}
+		head = head[:room]
+	}
+	return head + suffix
+}
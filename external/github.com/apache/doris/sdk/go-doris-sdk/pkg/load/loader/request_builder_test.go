@@ -0,0 +1,389 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+)
+
+// newTestConfig creates a valid configuration for building requests
+func newTestConfig() *config.Config {
+	return &config.Config{
+		Endpoints:   []string{"http://127.0.0.1:8030"},
+		User:        "root",
+		Database:    "test_db",
+		Table:       "test_table",
+		Format:      &config.JSONFormat{Type: config.JSONObjectLine},
+		GroupCommit: config.OFF,
+	}
+}
+
+func TestLabelDroppedUnderGroupCommit(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.GroupCommit = config.ASYNC
+	cfg.Label = "custom_label"
+	if err := cfg.ValidateInternal(); err != nil {
+		t.Fatalf("lenient label policy should pass validation: %v", err)
+	}
+
+	req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if label := req.Header.Get("label"); label != "" {
+		t.Errorf("label should be removed under group commit, got %q", label)
+	}
+	if mode := req.Header.Get("group_commit"); mode != "async_mode" {
+		t.Errorf("expected group_commit async_mode, got %q", mode)
+	}
+}
+
+func TestLabelKeptWithoutGroupCommit(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Label = "custom_label"
+
+	req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if label := req.Header.Get("label"); label != "custom_label" {
+		t.Errorf("expected label custom_label, got %q", label)
+	}
+}
+
+func TestCloudHeaders(t *testing.T) {
+	t.Run("configured", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.Warehouse = "wh1"
+		cfg.Cluster = "cluster1"
+		req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		if got := req.Header.Get(WarehouseHeader); got != "wh1" {
+			t.Errorf("expected warehouse header wh1, got %q", got)
+		}
+		if got := req.Header.Get(ClusterHeader); got != "cluster1" {
+			t.Errorf("expected cloud_cluster header cluster1, got %q", got)
+		}
+	})
+
+	t.Run("absent by default", func(t *testing.T) {
+		req, err := CreateStreamLoadRequest(newTestConfig(), strings.NewReader(""), 0)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		for _, header := range []string{WarehouseHeader, ClusterHeader} {
+			if _, ok := req.Header[http.CanonicalHeaderKey(header)]; ok {
+				t.Errorf("header %s should not be set", header)
+			}
+		}
+	})
+}
+
+func TestContentTypePerFormat(t *testing.T) {
+	testCases := []struct {
+		format      config.Format
+		contentType string
+	}{
+		{format: &config.JSONFormat{Type: config.JSONObjectLine}, contentType: "application/json"},
+		{format: &config.JSONFormat{Type: config.JSONArray}, contentType: "application/json"},
+		{format: &config.CSVFormat{ColumnSeparator: ",", LineDelimiter: "\n"}, contentType: "text/plain"},
+	}
+
+	for _, tc := range testCases {
+		cfg := newTestConfig()
+		cfg.Format = tc.format
+		req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		if got := req.Header.Get("Content-Type"); got != tc.contentType {
+			t.Errorf("expected Content-Type %q for %s format, got %q", tc.contentType, tc.format.GetFormatType(), got)
+		}
+	}
+}
+
+func TestLoadTimeoutHeader(t *testing.T) {
+	loadTimeout := 3600
+
+	testCases := []struct {
+		name        string
+		loadTimeout *int
+		options     map[string]string
+		expected    string
+	}{
+		{name: "unset", expected: ""},
+		{name: "typed field", loadTimeout: &loadTimeout, expected: "3600"},
+		{name: "typed field overrides options", loadTimeout: &loadTimeout, options: map[string]string{"timeout": "60"}, expected: "3600"},
+		{name: "options only", options: map[string]string{"timeout": "60"}, expected: "60"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.LoadTimeoutSeconds = tc.loadTimeout
+			cfg.Options = tc.options
+			req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if got := req.Header.Get("timeout"); got != tc.expected {
+				t.Fatalf("expected timeout header %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestExecMemLimitHeader(t *testing.T) {
+	cfg := newTestConfig()
+	memLimit := int64(4 << 30)
+	cfg.ExecMemLimitBytes = &memLimit
+	cfg.Options = map[string]string{"exec_mem_limit": "1024"}
+
+	req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if got := req.Header.Get("exec_mem_limit"); got != "4294967296" {
+		t.Fatalf("expected exec_mem_limit header 4294967296, got %q", got)
+	}
+}
+
+func TestTimezoneHeader(t *testing.T) {
+	for _, timezone := range []string{"", "Asia/Shanghai", "+08:00"} {
+		cfg := newTestConfig()
+		cfg.Timezone = timezone
+		req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		if got := req.Header.Get("timezone"); got != timezone {
+			t.Errorf("expected timezone header %q, got %q", timezone, got)
+		}
+	}
+}
+
+func TestGeneratedLabelFitsDorisConstraints(t *testing.T) {
+	testCases := []struct {
+		name       string
+		prefix     string
+		table      string
+		maxLength  int
+		wantPrefix string
+	}{
+		{name: "short prefix kept", prefix: "app", table: "test_table", wantPrefix: "app_test_db_test_table_"},
+		{name: "invalid characters replaced", prefix: "my app/v1.2", table: "日志", wantPrefix: "my_app_v1_2_test_db____"},
+		{name: "over-length prefix truncated", prefix: strings.Repeat("p", 200), table: "test_table", wantPrefix: strings.Repeat("p", 40)},
+		{name: "custom max length", prefix: strings.Repeat("p", 100), table: "test_table", maxLength: 64},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.LabelPrefix = tc.prefix
+			cfg.Table = tc.table
+			cfg.MaxLabelLength = tc.maxLength
+
+			seen := make(map[string]bool)
+			for attempt := 0; attempt < 3; attempt++ {
+				label := generateLabel(cfg, attempt)
+				if len(label) > cfg.GetMaxLabelLength() {
+					t.Errorf("label %q is longer than %d", label, cfg.GetMaxLabelLength())
+				}
+				for _, r := range label {
+					if !(r == '-' || r == '_' || r == ':' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')) {
+						t.Errorf("label %q contains invalid character %q", label, r)
+					}
+				}
+				if !strings.HasPrefix(label, tc.wantPrefix) {
+					t.Errorf("expected label %q to start with %q", label, tc.wantPrefix)
+				}
+				if seen[label] {
+					t.Errorf("label %q is not unique", label)
+				}
+				seen[label] = true
+			}
+		})
+	}
+}
+
+func TestCustomLabelRetryFitsMaxLength(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Label = strings.Repeat("l", config.DefaultMaxLabelLength)
+
+	if label := generateLabel(cfg, 0); label != cfg.Label {
+		t.Errorf("first attempt should use the custom label, got %q", label)
+	}
+	label := generateLabel(cfg, 1)
+	if len(label) > config.DefaultMaxLabelLength || !strings.Contains(label, "_retry_1_") {
+		t.Errorf("unexpected retry label %q", label)
+	}
+}
+
+func TestJSONColumnsHeader(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Format = &config.JSONFormat{Type: config.JSONObjectLine, Columns: []string{"message", "ts"}}
+
+	req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	if got := req.Header.Get("columns"); got != "message,ts" {
+		t.Errorf("expected columns header message,ts, got %q", got)
+	}
+}
+
+func TestDeleteSignHeaders(t *testing.T) {
+	testCases := []struct {
+		name          string
+		deleteSign    bool
+		columns       []string
+		hiddenColumns string
+		columnsHeader string
+	}{
+		{name: "disabled"},
+		{name: "hidden column", deleteSign: true, hiddenColumns: config.DeleteSignColumn},
+		{name: "explicit columns", deleteSign: true, columns: []string{"id", "name"}, columnsHeader: "id,name," + config.DeleteSignColumn},
+		{name: "listed in columns", deleteSign: true, columns: []string{"id", config.DeleteSignColumn}, columnsHeader: "id," + config.DeleteSignColumn},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.DeleteSign = tc.deleteSign
+			cfg.Format = &config.JSONFormat{Type: config.JSONObjectLine, Columns: tc.columns}
+			req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if got := req.Header.Get("hidden_columns"); got != tc.hiddenColumns {
+				t.Errorf("expected hidden_columns header %q, got %q", tc.hiddenColumns, got)
+			}
+			if got := req.Header.Get("columns"); got != tc.columnsHeader {
+				t.Errorf("expected columns header %q, got %q", tc.columnsHeader, got)
+			}
+		})
+	}
+}
+
+func TestConstantColumnsHeader(t *testing.T) {
+	testCases := []struct {
+		name          string
+		constants     map[string]string
+		deleteSign    bool
+		columnsHeader string
+	}{
+		{name: "none", columnsHeader: "id,message"},
+		{name: "sorted by name", constants: map[string]string{"region": "eu", "env": "prod"},
+			columnsHeader: "id,message,env='prod',region='eu'"},
+		{name: "quoted value", constants: map[string]string{"owner": `o'brien\`}, columnsHeader: `id,message,owner='o\'brien\\'`},
+		{name: "with delete sign", constants: map[string]string{"env": "prod"}, deleteSign: true,
+			columnsHeader: "id,message,env='prod'," + config.DeleteSignColumn},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.Format = &config.JSONFormat{Type: config.JSONObjectLine, Columns: []string{"id", "message"}}
+			cfg.ConstantColumns = tc.constants
+			cfg.DeleteSign = tc.deleteSign
+			req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if got := req.Header.Get("columns"); got != tc.columnsHeader {
+				t.Errorf("expected columns header %q, got %q", tc.columnsHeader, got)
+			}
+		})
+	}
+}
+
+func TestEndpointScheme(t *testing.T) {
+	for _, endpoint := range []string{"http://127.0.0.1:8030", "https://127.0.0.1:8031"} {
+		cfg := newTestConfig()
+		cfg.Endpoints = []string{endpoint}
+
+		requests := make([]*http.Request, 0, 4)
+		for _, create := range []func() (*http.Request, error){
+			func() (*http.Request, error) { return CreateStreamLoadRequest(cfg, strings.NewReader(""), 0) },
+			func() (*http.Request, error) { return CreateAbortTransactionRequest(cfg, 1) },
+			func() (*http.Request, error) { return CreateLoadStateRequest(cfg, "label") },
+			func() (*http.Request, error) { return CreateSchemaRequest(cfg) },
+		} {
+			req, err := create()
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			requests = append(requests, req)
+		}
+		for _, req := range requests {
+			if got := req.URL.Scheme + "://" + req.URL.Host; got != endpoint {
+				t.Errorf("expected request to %s, got %s", endpoint, req.URL)
+			}
+		}
+	}
+}
+
+func TestPathPrefix(t *testing.T) {
+	testCases := []struct {
+		name       string
+		prefix     string
+		streamLoad string
+		loadState  string
+	}{
+		{
+			name:       "default",
+			streamLoad: "http://127.0.0.1:8030/api/test_db/test_table/_stream_load",
+			loadState:  "http://127.0.0.1:8030/api/test_db/get_load_state?label=label",
+		},
+		{
+			name:       "prefix",
+			prefix:     "/gateway/doris",
+			streamLoad: "http://127.0.0.1:8030/gateway/doris/api/test_db/test_table/_stream_load",
+			loadState:  "http://127.0.0.1:8030/gateway/doris/api/test_db/get_load_state?label=label",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.Endpoints = []string{"http://127.0.0.1:8030"}
+			cfg.PathPrefix = tc.prefix
+
+			req, err := CreateStreamLoadRequest(cfg, strings.NewReader(""), 0)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if req.URL.String() != tc.streamLoad {
+				t.Errorf("expected stream load to %s, got %s", tc.streamLoad, req.URL)
+			}
+			req, err = CreateLoadStateRequest(cfg, "label")
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if req.URL.String() != tc.loadState {
+				t.Errorf("expected load state request to %s, got %s", tc.loadState, req.URL)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+type LoadResponse struct {
+	Status       LoadStatus
+	Resp         RespContent
+	ErrorMessage string
+	// Label is the label sent with the last attempt, set even when Doris did not respond
+	// It is empty under group commit, which does not allow labels
+	Label string
+	// Duration is the wall time of the whole load including retries and backoff
+	Duration time.Duration
+	// Attempts is the number of requests sent, 1 plus the number of retries
+	Attempts int
+	// Err is the error returned by the client with a failed response
+	Err error
+}
+
+// IsRetriable reports whether a failed load may succeed when sent again, for callers retrying loads themselves,
+// e.g. by queueing them. It uses the classification of the retries of the client, without Config.RetryableMessages
+// Successful loads are not retriable
+func (r *LoadResponse) IsRetriable() bool {
+	if r.Status == SUCCESS {
+		return false
+	}
+	return IsRetryable(r.Err, r)
+}
+
+// HasWarnings reports whether Doris filtered rows or attached a comment or error URL, which successful loads can also carry
+func (r *LoadResponse) HasWarnings() bool {
+	return r.Resp.NumberFilteredRows > 0 || r.Resp.ErrorURL != "" || r.Resp.Comment != ""
+}
+
+// FilteredRatio returns the share of the total rows filtered by Doris, 0 if no rows were read
+func (r *LoadResponse) FilteredRatio() float64 {
+	if r.Resp.NumberTotalRows <= 0 {
+		return 0
+	}
+	return float64(r.Resp.NumberFilteredRows) / float64(r.Resp.NumberTotalRows)
+}
+
+type LoadStatus int
+
+const (
+	FAILURE LoadStatus = iota
+	SUCCESS
+)
+
+// String returns the string representation of LoadStatus
+func (s LoadStatus) String() string {
+	switch s {
+	case SUCCESS:
+		return "SUCCESS"
+	case FAILURE:
+		return "FAILURE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Status values of RespContent returned by Doris
+const (
+	StatusSuccess            = "Success"
+	StatusPublishTimeout     = "Publish Timeout"
+	StatusLabelAlreadyExists = "Label Already Exists"
+	StatusFail               = "Fail"
+)
+
+// ExistingJobStatus values of a RespContent with StatusLabelAlreadyExists
+const (
+	ExistingJobRunning   = "RUNNING"
+	ExistingJobFinished  = "FINISHED"
+	ExistingJobVisible   = "VISIBLE"
+	ExistingJobCancelled = "CANCELLED"
+)
+
+// States of a load returned by the get_load_state API
+const (
+	LoadStateUnknown      = "UNKNOWN"
+	LoadStatePrepare      = "PREPARE"
+	LoadStatePrecommitted = "PRECOMMITTED"
+	LoadStateCommitted    = "COMMITTED"
+	LoadStateVisible      = "VISIBLE"
+	LoadStateAborted      = "ABORTED"
+)
+
+// RespContent represents the response from a stream load operation
+type RespContent struct {
+	TxnID                  int64  `json:"TxnId"`
+	Label                  string `json:"Label"`
+	Status                 string `json:"Status"`
+	TwoPhaseCommit         string `json:"TwoPhaseCommit"`
+	ExistingJobStatus      string `json:"ExistingJobStatus"`
+	Message                string `json:"Message"`
+	NumberTotalRows        int64  `json:"NumberTotalRows"`
+	NumberLoadedRows       int64  `json:"NumberLoadedRows"`
+	NumberFilteredRows     int    `json:"NumberFilteredRows"`
+	NumberUnselectedRows   int    `json:"NumberUnselectedRows"`
+	LoadBytes              int64  `json:"LoadBytes"`
+	LoadTimeMs             int    `json:"LoadTimeMs"`
+	BeginTxnTimeMs         int    `json:"BeginTxnTimeMs"`
+	StreamLoadPutTimeMs    int    `json:"StreamLoadPutTimeMs"`
+	ReadDataTimeMs         int    `json:"ReadDataTimeMs"`
+	WriteDataTimeMs        int    `json:"WriteDataTimeMs"`
+	CommitAndPublishTimeMs int    `json:"CommitAndPublishTimeMs"`
+	ErrorURL               string `json:"ErrorURL"`
+	Comment                string `json:"Comment"`
+}
+
+// String returns a JSON representation of the response content
+func (r *RespContent) String() string {
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return ""
+	}
+	return string(bytes)
+}
+
+// TotalServerTime returns the total time Doris spent on the load
+func (r *RespContent) TotalServerTime() time.Duration {
+	return time.Duration(r.LoadTimeMs) * time.Millisecond
+}
+
+// QueueTime returns the part of the total server time not spent in any reported phase,
+// i.e. waiting for resources before and between beginning the transaction, planning, writing and publishing
+// ReadDataTimeMs is not subtracted since reading the data is part of writing it
+func (r *RespContent) QueueTime() time.Duration {
+	phasesMs := r.BeginTxnTimeMs + r.StreamLoadPutTimeMs + r.WriteDataTimeMs + r.CommitAndPublishTimeMs
+	queueMs := r.LoadTimeMs - phasesMs
+	if queueMs < 0 {
+		return 0
+	}
+	return time.Duration(queueMs) * time.Millisecond
+}
+
+// IngestRate returns the loaded bytes per second over the total server time, 0 if the load time is unknown
+func (r *RespContent) IngestRate() float64 {
+	if r.LoadTimeMs <= 0 {
+		return 0
+	}
+	return float64(r.LoadBytes) * 1000 / float64(r.LoadTimeMs)
+}
+
+// IngestRowRate returns the loaded rows per second over the total server time, 0 if the load time is unknown
+func (r *RespContent) IngestRowRate() float64 {
+	if r.LoadTimeMs <= 0 {
+		return 0
+	}
+	return float64(r.NumberLoadedRows) * 1000 / float64(r.LoadTimeMs)
+}
+
+// ColumnInfo describes a column of a table as returned by the FE schema API
+type ColumnInfo struct {
+	Name     string
+	Type     string
+	Nullable bool
+}
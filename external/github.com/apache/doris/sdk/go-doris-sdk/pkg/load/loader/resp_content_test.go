@@ -0,0 +1,163 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/exception"
+)
+
+func TestRespContentMetrics(t *testing.T) {
+	testCases := []struct {
+		name            string
+		resp            RespContent
+		totalServerTime time.Duration
+		queueTime       time.Duration
+		ingestRate      float64
+		ingestRowRate   float64
+	}{
+		{
+			name: "typical load",
+			resp: RespContent{
+				NumberLoadedRows:       1000,
+				LoadBytes:              2 * 1024 * 1024,
+				LoadTimeMs:             500,
+				BeginTxnTimeMs:         10,
+				StreamLoadPutTimeMs:    40,
+				ReadDataTimeMs:         200,
+				WriteDataTimeMs:        300,
+				CommitAndPublishTimeMs: 50,
+			},
+			totalServerTime: 500 * time.Millisecond,
+			queueTime:       100 * time.Millisecond,
+			ingestRate:      4 * 1024 * 1024,
+			ingestRowRate:   2000,
+		},
+		{
+			name:            "zero load time",
+			resp:            RespContent{NumberLoadedRows: 10, LoadBytes: 100},
+			totalServerTime: 0,
+			queueTime:       0,
+			ingestRate:      0,
+			ingestRowRate:   0,
+		},
+		{
+			name: "phases longer than total",
+			resp: RespContent{
+				NumberLoadedRows: 1,
+				LoadBytes:        10,
+				LoadTimeMs:       100,
+				WriteDataTimeMs:  120,
+			},
+			totalServerTime: 100 * time.Millisecond,
+			queueTime:       0,
+			ingestRate:      100,
+			ingestRowRate:   10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.resp.TotalServerTime(); got != tc.totalServerTime {
+				t.Errorf("TotalServerTime: expected %v, got %v", tc.totalServerTime, got)
+			}
+			if got := tc.resp.QueueTime(); got != tc.queueTime {
+				t.Errorf("QueueTime: expected %v, got %v", tc.queueTime, got)
+			}
+			if got := tc.resp.IngestRate(); got != tc.ingestRate {
+				t.Errorf("IngestRate: expected %v, got %v", tc.ingestRate, got)
+			}
+			if got := tc.resp.IngestRowRate(); got != tc.ingestRowRate {
+				t.Errorf("IngestRowRate: expected %v, got %v", tc.ingestRowRate, got)
+			}
+		})
+	}
+}
+
+func TestLoadResponseWarnings(t *testing.T) {
+	testCases := []struct {
+		name          string
+		resp          RespContent
+		hasWarnings   bool
+		filteredRatio float64
+	}{
+		{name: "clean load", resp: RespContent{NumberTotalRows: 100, NumberLoadedRows: 100}},
+		{
+			name:          "filtered rows",
+			resp:          RespContent{NumberTotalRows: 100, NumberLoadedRows: 75, NumberFilteredRows: 25, ErrorURL: "http://be:8040/api/_load_error_log?file=x"},
+			hasWarnings:   true,
+			filteredRatio: 0.25,
+		},
+		{name: "comment only", resp: RespContent{NumberTotalRows: 10, NumberLoadedRows: 10, Comment: "partial columns"}, hasWarnings: true},
+		{name: "no rows", resp: RespContent{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := &LoadResponse{Status: SUCCESS, Resp: tc.resp}
+			if got := response.HasWarnings(); got != tc.hasWarnings {
+				t.Errorf("HasWarnings: expected %t, got %t", tc.hasWarnings, got)
+			}
+			if got := response.FilteredRatio(); got != tc.filteredRatio {
+				t.Errorf("FilteredRatio: expected %v, got %v", tc.filteredRatio, got)
+			}
+		})
+	}
+}
+
+func TestLoadResponseIsRetriable(t *testing.T) {
+	connErr := exception.NewConnectionError("failed to execute request: dial tcp 127.0.0.1:8030: connect: connection refused", nil)
+	testCases := []struct {
+		name      string
+		response  *LoadResponse
+		retriable bool
+	}{
+		{name: "success", response: &LoadResponse{Status: SUCCESS}},
+		{name: "connection refused", response: &LoadResponse{Status: FAILURE, ErrorMessage: connErr.Error(), Err: connErr}, retriable: true},
+		{name: "backend unavailable", response: &LoadResponse{Status: FAILURE, ErrorMessage: "backend unavailable"}, retriable: true},
+		{name: "timeout", response: &LoadResponse{Status: FAILURE, ErrorMessage: "[TIMEOUT]load timed out"}, retriable: true},
+		{
+			name:     "data quality",
+			response: &LoadResponse{Status: FAILURE, ErrorMessage: "too many filtered rows", Err: exception.NewDataQualityError("too many filtered rows")},
+		},
+		{
+			name:     "auth",
+			response: &LoadResponse{Status: FAILURE, ErrorMessage: "access denied", Err: exception.NewAuthError("access denied")},
+		},
+		{
+			name:     "label exists",
+			response: &LoadResponse{Status: FAILURE, ErrorMessage: "label already exists", Err: exception.NewLabelExistsError("label already exists", "FINISHED")},
+		},
+		{
+			name: "outcome unknown",
+			response: &LoadResponse{Status: FAILURE, ErrorMessage: "connection broke after the data was sent",
+				Err: exception.NewOutcomeUnknownError("connection broke after the data was sent: connection reset by peer", "label", connErr)},
+		},
+		{name: "parse error", response: &LoadResponse{Status: FAILURE, ErrorMessage: "[INVALID_ARGUMENT]parse json failed"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.response.IsRetriable(); got != tc.retriable {
+				t.Errorf("expected retriable %t, got %t", tc.retriable, got)
+			}
+		})
+	}
+}
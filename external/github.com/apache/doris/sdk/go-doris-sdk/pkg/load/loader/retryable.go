@@ -0,0 +1,102 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/exception"
+)
+
+// Pre-compiled error patterns for efficient matching
+var (
+	retryableErrorPatterns = []string{
+		"connection refused",
+		"connection reset",
+		"connection timeout",
+		"timeout",
+		"network is unreachable",
+		"no such host",
+		"temporary failure",
+		"dial tcp",
+		"i/o timeout",
+		"eof",
+		"broken pipe",
+		"connection aborted",
+		"307 temporary redirect",
+		"302 found",
+		"301 moved permanently",
+	}
+
+	retryableResponsePatterns = []string{
+		"connect",
+		"unavailable",
+		"timeout",
+		"redirect",
+	}
+)
+
+// IsRetryable reports whether a failed load may succeed when sent again, as decided by the retries of the client
+// Only network/connection issues and unavailable responses are retried
+func IsRetryable(err error, response *LoadResponse) bool {
+	// Typed errors of rejected loads will fail again with the same data and credentials
+	var authErr *exception.AuthError
+	var dataQualityErr *exception.DataQualityError
+	var labelExistsErr *exception.LabelExistsError
+	var outcomeErr *exception.OutcomeUnknownError
+	if errors.As(err, &authErr) || errors.As(err, &dataQualityErr) || errors.As(err, &labelExistsErr) || errors.As(err, &outcomeErr) {
+		return false
+	}
+
+	// Errors of failed responses are classified by the response message
+	if err != nil && (response == nil || response.Status != FAILURE) {
+		// Avoid ToLower allocation by checking original error first
+		errStr := err.Error()
+
+		// Check net.Error interface first (most efficient)
+		if netErr, ok := err.(net.Error); ok {
+			if netErr.Timeout() || netErr.Temporary() {
+				return true
+			}
+		}
+
+		// Only convert to lowercase if necessary
+		errStrLower := strings.ToLower(errStr)
+		for _, pattern := range retryableErrorPatterns {
+			if strings.Contains(errStrLower, pattern) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	// If the response indicates failure, check if it's a retryable response error
+	if response != nil && response.Status == FAILURE && response.ErrorMessage != "" {
+		errMsgLower := strings.ToLower(response.ErrorMessage)
+		for _, pattern := range retryableResponsePatterns {
+			if strings.Contains(errMsgLower, pattern) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
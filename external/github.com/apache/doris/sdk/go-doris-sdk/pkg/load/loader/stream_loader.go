@@ -0,0 +1,427 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/exception"
+	log "github.com/apache/doris/sdk/go-doris-sdk/pkg/load/logger"
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/util"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// ErrResponseTooLarge is returned when a response body exceeds the maximum response size
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// maxRawBodyBytes bounds the part of a response body that is not JSON included in the returned error
+const maxRawBodyBytes = 1024
+
+// Message patterns of failed stream load responses, used to classify the returned error
+var (
+	authFailurePatterns = []string{
+		"access denied",
+		"unauthorized",
+		"authenticate failed",
+		"authentication failed",
+	}
+
+	dataQualityPatterns = []string{
+		"data_quality_error",
+		"too many filtered rows",
+		"data quality",
+	}
+)
+
+// StreamLoader handles loading data into Doris via HTTP stream load
+type StreamLoader struct {
+	httpClient       *http.Client
+	json             jsoniter.API
+	maxResponseBytes int64
+}
+
+// NewStreamLoader creates a new StreamLoader
+func NewStreamLoader() *StreamLoader {
+	return NewStreamLoaderWithClient(util.GetHttpClient())
+}
+
+// NewStreamLoaderWithClient creates a new StreamLoader sending requests with the given HTTP client
+func NewStreamLoaderWithClient(httpClient *http.Client) *StreamLoader {
+	return &StreamLoader{
+		httpClient:       httpClient,
+		json:             jsoniter.ConfigCompatibleWithStandardLibrary,
+		maxResponseBytes: config.DefaultMaxResponseBytes,
+	}
+}
+
+// SetMaxResponseBytes sets the largest response body read, larger responses fail with ErrResponseTooLarge
+func (s *StreamLoader) SetMaxResponseBytes(maxBytes int64) {
+	if maxBytes > 0 {
+		s.maxResponseBytes = maxBytes
+	}
+}
+
+// readResponseBody reads a response body up to the configured limit
+func (s *StreamLoader) readResponseBody(body io.Reader) ([]byte, error) {
+	// Read one byte past the limit to tell whether the body fits
+	data, err := io.ReadAll(io.LimitReader(body, s.maxResponseBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxResponseBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, s.maxResponseBytes)
+	}
+	return data, nil
+}
+
+// Load sends the HTTP request to Doris via stream load
+func (s *StreamLoader) Load(req *http.Request) (*LoadResponse, error) {
+	logger := requestLogger(req)
+
+	// Execute the request - this is the main performance bottleneck
+	logger.Debugf("Request: %s %s, headers: %v", req.Method, req.URL.Redacted(), util.RedactHeader(req.Header))
+	logger.Debugf("[TIMING] Sending HTTP request...")
+	requestStartTime := time.Now()
+	tracker := trackBody(req)
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		sent := tracker.sent()
+		logger.Errorf("Failed to execute HTTP request (body sent: %t): %v", sent, err)
+		connErr := exception.NewConnectionError(fmt.Sprintf("failed to execute request: %v", err), err)
+		connErr.BodySent = sent
+		return nil, connErr
+	}
+	defer resp.Body.Close()
+
+	requestDuration := time.Since(requestStartTime)
+	logger.Debugf("[TIMING] HTTP request completed: %v", requestDuration)
+
+	// Handle the response
+	result, err := s.handleResponse(resp, logger)
+
+	return result, err
+}
+
+// GetLoadState queries the state of a load, one of the LoadState values
+func (s *StreamLoader) GetLoadState(req *http.Request) (string, error) {
+	logger := requestLogger(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		logger.Errorf("Failed to execute load state request: %v", err)
+		return "", exception.NewConnectionError(fmt.Sprintf("failed to execute load state request: %v", err), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := s.readResponseBody(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read load state response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", exception.NewStreamLoadError(fmt.Sprintf("load state error: %s", resp.Status))
+	}
+
+	var result struct {
+		Data string `json:"data"`
+		Msg  string `json:"msg"`
+	}
+	if err := s.json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal load state response %s: %w", rawBody(body), err)
+	}
+	if result.Data == "" {
+		return "", exception.NewStreamLoadError(fmt.Sprintf("load state query failed: %s", result.Msg))
+	}
+	return result.Data, nil
+}
+
+// CheckSchema sends a schema request, checking that FE is reachable, accepts the credentials and knows the table
+func (s *StreamLoader) CheckSchema(req *http.Request) error {
+	_, err := s.DescribeSchema(req)
+	return err
+}
+
+// DescribeSchema sends a schema request and returns the columns of the table in their order
+func (s *StreamLoader) DescribeSchema(req *http.Request) ([]ColumnInfo, error) {
+	logger := requestLogger(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		logger.Errorf("Failed to execute schema request: %v", err)
+		return nil, exception.NewConnectionError(fmt.Sprintf("failed to execute schema request: %v", err), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := s.readResponseBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema response body: %w", err)
+	}
+	message := fmt.Sprintf("schema request error: %s", resp.Status)
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, exception.NewAuthError(message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, exception.NewStreamLoadError(message)
+	}
+
+	var result struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+		Data struct {
+			Properties []struct {
+				Name       string `json:"name"`
+				Type       string `json:"type"`
+				IsNullable string `json:"is_nullable"`
+			} `json:"properties"`
+		} `json:"data"`
+	}
+	if err := s.json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schema response %s: %w", rawBody(body), err)
+	}
+	if result.Code != 0 {
+		if containsAny(result.Msg, authFailurePatterns) {
+			return nil, exception.NewAuthError(fmt.Sprintf("schema request failed: %s", result.Msg))
+		}
+		return nil, exception.NewStreamLoadError(fmt.Sprintf("schema request failed: %s", result.Msg))
+	}
+
+	columns := make([]ColumnInfo, 0, len(result.Data.Properties))
+	for _, property := range result.Data.Properties {
+		columns = append(columns, ColumnInfo{
+			Name:     property.Name,
+			Type:     property.Type,
+			Nullable: strings.EqualFold(property.IsNullable, "yes"),
+		})
+	}
+	return columns, nil
+}
+
+// AbortTransaction sends the request aborting a two-phase commit transaction and checks its result
+func (s *StreamLoader) AbortTransaction(req *http.Request) error {
+	logger := requestLogger(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		logger.Errorf("Failed to execute abort request: %v", err)
+		return exception.NewConnectionError(fmt.Sprintf("failed to execute abort request: %v", err), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := s.readResponseBody(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read abort response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return exception.NewStreamLoadError(fmt.Sprintf("abort transaction error: %s", resp.Status))
+	}
+
+	var result struct {
+		Status string `json:"status"`
+		Msg    string `json:"msg"`
+	}
+	if err := s.json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to unmarshal abort response %s: %w", rawBody(body), err)
+	}
+	if !isSuccessStatus(result.Status) {
+		return exception.NewStreamLoadError(fmt.Sprintf("abort transaction failed: %s", result.Msg))
+	}
+	logger.Infof("Transaction aborted: %s", result.Msg)
+	return nil
+}
+
+// bodyTracker records whether a request body was read to the end by the transport
+type bodyTracker struct {
+	contentLength int64
+	read          atomic.Int64
+	done          atomic.Bool
+}
+
+// trackBody wraps the body of the request, including the bodies of redirected requests, in a bodyTracker
+func trackBody(req *http.Request) *bodyTracker {
+	tracker := &bodyTracker{contentLength: req.ContentLength}
+	if req.Body == nil || req.Body == http.NoBody {
+		tracker.done.Store(true)
+		return tracker
+	}
+	req.Body = &trackedBody{ReadCloser: req.Body, tracker: tracker}
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			tracker.read.Store(0)
+			tracker.done.Store(false)
+			return &trackedBody{ReadCloser: body, tracker: tracker}, nil
+		}
+	}
+	return tracker
+}
+
+// sent reports whether the whole body was read by the transport
+func (t *bodyTracker) sent() bool {
+	return t.done.Load() || (t.contentLength > 0 && t.read.Load() >= t.contentLength)
+}
+
+// trackedBody counts the bytes read from a request body
+type trackedBody struct {
+	io.ReadCloser
+	tracker *bodyTracker
+}
+
+// Read implements io.Reader
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.tracker.read.Add(int64(n))
+	if errors.Is(err, io.EOF) {
+		b.tracker.done.Store(true)
+	}
+	return n, err
+}
+
+// requestLogger creates a context logger carrying the endpoint, label and trace ID of the request
+func requestLogger(req *http.Request) *log.ContextLogger {
+	logger := log.NewContextLogger("StreamLoad").WithField("endpoint", req.URL.Host)
+	if label := req.Header.Get("label"); label != "" {
+		logger = logger.WithField("label", label)
+	}
+	if traceID := req.Header.Get(TraceIDHeader); traceID != "" {
+		logger = logger.WithField("trace_id", traceID)
+	}
+	return logger
+}
+
+// handleResponse processes the HTTP response from a stream load request
+func (s *StreamLoader) handleResponse(resp *http.Response, logger *log.ContextLogger) (*LoadResponse, error) {
+	statusCode := resp.StatusCode
+	logger.Debugf("Received HTTP response with status code: %d", statusCode)
+
+	if statusCode == http.StatusOK && resp.Body != nil {
+		// Read the response body with limited buffer
+		body, err := s.readResponseBody(resp.Body)
+		if err != nil {
+			logger.Errorf("Failed to read response body: %v", err)
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		logger.Infof("Stream Load Response: %s", string(body))
+
+		// Parse the response
+		var respContent RespContent
+		if err := s.json.Unmarshal(body, &respContent); err != nil {
+			logger.Errorf("Failed to unmarshal JSON response: %v", err)
+			return nil, fmt.Errorf("failed to unmarshal response %s: %w", rawBody(body), err)
+		}
+
+		// Check status and return result
+		if isSuccessStatus(respContent.Status) {
+			logger.Infof("Load operation completed successfully")
+			return &LoadResponse{
+				Status: SUCCESS,
+				Resp:   respContent,
+			}, nil
+		} else if isFinishedDuplicate(&respContent) {
+			// A previous load with the same label already committed this data, e.g. before a network error
+			logger.Infof("Label %s already exists with job status %s, treating as a successful idempotent retry",
+				respContent.Label, respContent.ExistingJobStatus)
+			return &LoadResponse{
+				Status: SUCCESS,
+				Resp:   respContent,
+			}, nil
+		} else {
+			logger.Errorf("Load operation failed with status: %s", respContent.Status)
+			errorMessage := ""
+			if respContent.Message != "" {
+				errorMessage = fmt.Sprintf("load failed. cause by: %s, please check more detail from url: %s",
+					respContent.Message, respContent.ErrorURL)
+			} else {
+				errorMessage = string(body)
+			}
+
+			return &LoadResponse{
+				Status:       FAILURE,
+				Resp:         respContent,
+				ErrorMessage: errorMessage,
+			}, newLoadFailureError(&respContent, errorMessage)
+		}
+	}
+
+	// For non-200 status codes, return an error that can be retried
+	logger.Errorf("Stream load failed with HTTP status: %s", resp.Status)
+
+	message := fmt.Sprintf("stream load error: %s", resp.Status)
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return nil, exception.NewAuthError(message)
+	}
+	return nil, exception.NewStreamLoadError(message)
+}
+
+// rawBody quotes the response body, truncated to maxRawBodyBytes, e.g. an HTML error page of a proxy
+func rawBody(body []byte) string {
+	if len(body) > maxRawBodyBytes {
+		return fmt.Sprintf("%q (truncated, %d bytes)", body[:maxRawBodyBytes], len(body))
+	}
+	return fmt.Sprintf("%q", body)
+}
+
+// newLoadFailureError classifies a failed stream load response into a typed error
+func newLoadFailureError(respContent *RespContent, message string) error {
+	switch {
+	case respContent.Status == StatusLabelAlreadyExists:
+		return exception.NewLabelExistsError(message, respContent.ExistingJobStatus)
+	case containsAny(respContent.Message, authFailurePatterns):
+		return exception.NewAuthError(message)
+	case respContent.NumberFilteredRows > 0 || containsAny(respContent.Message, dataQualityPatterns):
+		return exception.NewDataQualityError(message)
+	default:
+		return exception.NewStreamLoadError(message)
+	}
+}
+
+// containsAny reports whether the message contains any of the lower case patterns, ignoring case
+func containsAny(message string, patterns []string) bool {
+	messageLower := strings.ToLower(message)
+	for _, pattern := range patterns {
+		if strings.Contains(messageLower, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFinishedDuplicate checks if the label already exists and its job has committed the data
+// Other jobs are reported as a LabelExistsError, the client waits for the jobs that are not CANCELLED to finish
+func isFinishedDuplicate(respContent *RespContent) bool {
+	if respContent.Status != StatusLabelAlreadyExists {
+		return false
+	}
+	return strings.EqualFold(respContent.ExistingJobStatus, ExistingJobFinished) ||
+		strings.EqualFold(respContent.ExistingJobStatus, ExistingJobVisible)
+}
+
+// isSuccessStatus checks if the status indicates success
+func isSuccessStatus(status string) bool {
+	return strings.EqualFold(status, "success")
+}
@@ -0,0 +1,227 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/exception"
+)
+
+// loadFromMock sends a stream load request to a mock server replying with the given status code and body
+func loadFromMock(t *testing.T, statusCode int, body string) (*LoadResponse, error) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	return NewStreamLoader().Load(req)
+}
+
+func TestLoadErrorTypes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		body       string
+		check      func(err error) bool
+	}{
+		{
+			name:       "unauthorized status code",
+			statusCode: http.StatusUnauthorized,
+			check:      func(err error) bool { var e *exception.AuthError; return errors.As(err, &e) },
+		},
+		{
+			name:       "access denied message",
+			statusCode: http.StatusOK,
+			body:       `{"Status":"Fail","Message":"Access denied for user 'root'@'127.0.0.1'"}`,
+			check:      func(err error) bool { var e *exception.AuthError; return errors.As(err, &e) },
+		},
+		{
+			name:       "too many filtered rows",
+			statusCode: http.StatusOK,
+			body:       `{"Status":"Fail","Message":"[DATA_QUALITY_ERROR]too many filtered rows","NumberFilteredRows":10}`,
+			check:      func(err error) bool { var e *exception.DataQualityError; return errors.As(err, &e) },
+		},
+		{
+			name:       "label already exists",
+			statusCode: http.StatusOK,
+			body:       `{"Status":"Label Already Exists","ExistingJobStatus":"RUNNING","Message":"Label [l1] has already been used"}`,
+			check: func(err error) bool {
+				var e *exception.LabelExistsError
+				return errors.As(err, &e) && e.ExistingJobStatus == "RUNNING"
+			},
+		},
+		{
+			name:       "other failure",
+			statusCode: http.StatusInternalServerError,
+			check: func(err error) bool {
+				var authErr *exception.AuthError
+				var connErr *exception.ConnectionError
+				return !errors.As(err, &authErr) && !errors.As(err, &connErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := loadFromMock(t, tc.statusCode, tc.body)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !tc.check(err) {
+				t.Errorf("unexpected error type %T: %v", err, err)
+			}
+			var base *exception.StreamLoadError
+			if !errors.As(err, &base) {
+				t.Errorf("typed error should wrap StreamLoadError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestLoadConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	req, err := http.NewRequest(http.MethodPut, url, strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	_, err = NewStreamLoader().Load(req)
+
+	var connErr *exception.ConnectionError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("expected ConnectionError, got %T: %v", err, err)
+	}
+	if connErr.Cause == nil {
+		t.Errorf("ConnectionError should keep the underlying network error")
+	}
+	var base *exception.StreamLoadError
+	if !errors.As(err, &base) {
+		t.Errorf("ConnectionError should wrap StreamLoadError")
+	}
+}
+
+func TestLoadSuccessHasNoError(t *testing.T) {
+	resp, err := loadFromMock(t, http.StatusOK, `{"Status":"Success","NumberLoadedRows":1}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status != SUCCESS {
+		t.Errorf("expected SUCCESS, got %v", resp.Status)
+	}
+}
+
+func TestLabelAlreadyExistsIdempotency(t *testing.T) {
+	testCases := []struct {
+		existingJobStatus string
+		expectSuccess     bool
+	}{
+		{existingJobStatus: ExistingJobFinished, expectSuccess: true},
+		{existingJobStatus: ExistingJobVisible, expectSuccess: true},
+		{existingJobStatus: ExistingJobRunning, expectSuccess: false},
+		{existingJobStatus: ExistingJobCancelled, expectSuccess: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.existingJobStatus, func(t *testing.T) {
+			body := `{"Label":"l1","Status":"Label Already Exists","ExistingJobStatus":"` + tc.existingJobStatus + `","Message":"Label [l1] has already been used"}`
+			resp, err := loadFromMock(t, http.StatusOK, body)
+			if tc.expectSuccess {
+				if err != nil || resp.Status != SUCCESS {
+					t.Errorf("expected SUCCESS for %s, got %v, err: %v", tc.existingJobStatus, resp.Status, err)
+				}
+				return
+			}
+			var labelExistsErr *exception.LabelExistsError
+			if !errors.As(err, &labelExistsErr) || resp.Status != FAILURE {
+				t.Errorf("expected LabelExistsError for %s, got %v, err: %v", tc.existingJobStatus, resp.Status, err)
+			}
+		})
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	body := `{"Status":"Success","NumberLoadedRows":1,"Message":"` + strings.Repeat("x", 100) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		maxBytes int64
+		wantErr  bool
+	}{
+		{name: "fits", maxBytes: int64(len(body))},
+		{name: "exceeds", maxBytes: int64(len(body)) - 1, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"a":1}`))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			streamLoader := NewStreamLoader()
+			streamLoader.SetMaxResponseBytes(tc.maxBytes)
+
+			_, err = streamLoader.Load(req)
+			if tc.wantErr != errors.Is(err, ErrResponseTooLarge) {
+				t.Fatalf("expected ErrResponseTooLarge: %t, got %v", tc.wantErr, err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestUnmarshalFailureIncludesRawBody(t *testing.T) {
+	page := "<html><body>502 Bad Gateway</body></html>"
+	testCases := []struct {
+		name     string
+		body     string
+		contains string
+	}{
+		{name: "html page", body: page, contains: page},
+		{name: "truncated", body: strings.Repeat("x", 4*maxRawBodyBytes), contains: "(truncated, 4096 bytes)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := loadFromMock(t, http.StatusOK, tc.body)
+			if err == nil || !strings.Contains(err.Error(), tc.contains) {
+				t.Fatalf("expected error containing %q, got %v", tc.contains, err)
+			}
+			if len(tc.body) > maxRawBodyBytes && len(err.Error()) >= len(tc.body) {
+				t.Errorf("expected the body to be truncated, got %d bytes", len(err.Error()))
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package load
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load/config"
+)
+
+// traceRecorder collects the timing of the phases of a request
+// The httptrace hooks may be called from different goroutines, e.g. when dialing several addresses
+type traceRecorder struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	trace        config.LoadTrace
+}
+
+// WithTrace returns a copy of the request recording the timing of its phases,
+// and a function returning the collected trace once the request is done
+func WithTrace(req *http.Request, attempt int) (*http.Request, func() config.LoadTrace) {
+	recorder := &traceRecorder{start: time.Now()}
+	recorder.trace.Endpoint = req.URL.Host
+	recorder.trace.Label = req.Header.Get("label")
+	recorder.trace.Attempt = attempt
+
+	clientTrace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			recorder.record(func() { recorder.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			recorder.record(func() { recorder.trace.DNS = time.Since(recorder.dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			recorder.record(func() { recorder.connectStart = time.Now() })
+		},
+		ConnectDone: func(string, string, error) {
+			recorder.record(func() { recorder.trace.Connect = time.Since(recorder.connectStart) })
+		},
+		TLSHandshakeStart: func() {
+			recorder.record(func() { recorder.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			recorder.record(func() { recorder.trace.TLSHandshake = time.Since(recorder.tlsStart) })
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			recorder.record(func() { recorder.trace.ReusedConn = info.Reused })
+		},
+		GotFirstResponseByte: func() {
+			recorder.record(func() { recorder.trace.FirstByte = time.Since(recorder.start) })
+		},
+	}
+
+	tracedReq := req.WithContext(httptrace.WithClientTrace(req.Context(), clientTrace))
+	return tracedReq, func() config.LoadTrace {
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		recorder.trace.Total = time.Since(recorder.start)
+		return recorder.trace
+	}
+}
+
+// record updates the recorder under its lock
+func (r *traceRecorder) record(update func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	update()
+}
@@ -0,0 +1,392 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package log provides a simple logging interface for the Doris Stream Load Client
+// Enhanced with millisecond precision and goroutine tracking for concurrent scenarios
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogFunc is a function type for logging with formatting
+type LogFunc func(format string, args ...interface{})
+
+// Level represents log levels
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the string representation of the log level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO "
+	case LevelWarn:
+		return "WARN "
+	case LevelError:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Format represents the output format of the default logger
+type Format int
+
+const (
+	// FormatText outputs human readable lines: [TIMESTAMP] [LEVEL] [goroutine-ID] [file:line] message
+	FormatText Format = iota
+	// FormatJSON outputs one JSON object per line with structured fields
+	FormatJSON
+)
+
+// Logger is the interface of an application logger that the SDK logs can be routed to
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// Field is a key-value pair attached to the log lines of a ContextLogger
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Global logging configuration
+var (
+	// Current minimum log level
+	// Default to INFO for security reasons (DEBUG may expose sensitive information like passwords)
+	currentLevel = LevelInfo
+
+	// Current output format of the default logger, text by default for backward compatibility
+	currentFormat = FormatText
+
+	// Levels whose logging function has been replaced by the user
+	customized = map[Level]bool{}
+
+	// Enhanced logger with custom formatter
+	stdLogger = log.New(os.Stdout, "", 0) // Remove default flags, we'll format ourselves
+
+	// Debug logging function - can be customized
+	DebugFunc LogFunc = defaultLogFunc(LevelDebug)
+
+	// Info logging function - can be customized
+	InfoFunc LogFunc = defaultLogFunc(LevelInfo)
+
+	// Warn logging function - can be customized
+	WarnFunc LogFunc = defaultLogFunc(LevelWarn)
+
+	// Error logging function - can be customized
+	ErrorFunc LogFunc = defaultLogFunc(LevelError)
+)
+
+// getGoroutineID returns the current goroutine ID for log tracking
+func getGoroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	// Parse "goroutine 123 [running]:"
+	buf = buf[len("goroutine "):]
+	idx := 0
+	for i, b := range buf {
+		if b < '0' || b > '9' {
+			idx = i
+			break
+		}
+	}
+	id, _ := strconv.ParseUint(string(buf[:idx]), 10, 64)
+	return id
+}
+
+// formatTimestamp returns a timestamp with millisecond precision
+func formatTimestamp() string {
+	now := time.Now()
+	return now.Format("2006/01/02 15:04:05.000")
+}
+
+// getCallerInfo returns the file and line number of the caller
+func getCallerInfo() string {
+	// We need to skip more levels to get to the actual caller
+	// Let's try different skip levels to find the right one
+	for skip := 3; skip <= 8; skip++ {
+		_, file, line, ok := runtime.Caller(skip)
+		if !ok {
+			continue
+		}
+
+		// Only show the filename, not the full path
+		if idx := strings.LastIndex(file, "/"); idx >= 0 {
+			file = file[idx+1:]
+		}
+
+		// Skip our own log package files and go runtime files
+		if !strings.Contains(file, "log.go") &&
+			!strings.Contains(file, "asm_") &&
+			!strings.Contains(file, "proc.go") {
+			return fmt.Sprintf("%s:%d", file, line)
+		}
+	}
+
+	// Fallback if we can't find the right caller
+	return "unknown:0"
+}
+
+// defaultLogFunc creates an enhanced logging function for the given level
+func defaultLogFunc(level Level) LogFunc {
+	return func(format string, args ...interface{}) {
+		if level >= currentLevel {
+			var message string
+			if len(args) == 0 {
+				message = format
+			} else {
+				message = fmt.Sprintf(format, args...)
+			}
+			writeLog(level, "", nil, message)
+		}
+	}
+}
+
+// writeLog formats a single line in the current format and writes it to the default logger
+func writeLog(level Level, context string, fields []Field, message string) {
+	timestamp := formatTimestamp()
+	gid := getGoroutineID()
+	caller := getCallerInfo()
+
+	if currentFormat == FormatJSON {
+		entry := make(map[string]interface{}, len(fields)+6)
+		for _, field := range fields {
+			entry[field.Key] = field.Value
+		}
+		entry["time"] = timestamp
+		entry["level"] = strings.TrimSpace(level.String())
+		entry["goroutine"] = gid
+		entry["caller"] = caller
+		entry["msg"] = message
+		if context != "" {
+			entry["context"] = context
+		}
+		line, err := json.Marshal(entry)
+		if err == nil {
+			stdLogger.Output(1, string(line))
+			return
+		}
+		// Fall back to text format if some field cannot be marshaled
+	}
+
+	// Enhanced format: [TIMESTAMP] [LEVEL] [goroutine-ID] [file:line] message
+	logLine := fmt.Sprintf("[%s] [%s] [G-%d] [%s] %s",
+		timestamp, level.String(), gid, caller, message)
+
+	stdLogger.Output(1, logLine)
+}
+
+// SetLevel sets the minimum log level
+func SetLevel(level Level) {
+	currentLevel = level
+}
+
+// SetFormat sets the output format of the default logger
+// Custom logging functions always receive the formatted text message
+func SetFormat(format Format) {
+	currentFormat = format
+}
+
+// SetOutput sets the output destination for the default logger
+func SetOutput(output io.Writer) {
+	stdLogger.SetOutput(output)
+}
+
+// SetDebugFunc sets a custom debug logging function
+func SetDebugFunc(fn LogFunc) {
+	DebugFunc = fn
+	customized[LevelDebug] = true
+}
+
+// SetInfoFunc sets a custom info logging function
+func SetInfoFunc(fn LogFunc) {
+	InfoFunc = fn
+	customized[LevelInfo] = true
+}
+
+// SetWarnFunc sets a custom warn logging function
+func SetWarnFunc(fn LogFunc) {
+	WarnFunc = fn
+	customized[LevelWarn] = true
+}
+
+// SetErrorFunc sets a custom error logging function
+func SetErrorFunc(fn LogFunc) {
+	ErrorFunc = fn
+	customized[LevelError] = true
+}
+
+// SetLogger routes all SDK logs to the given logger
+// Passing nil restores the default logger
+func SetLogger(l Logger) {
+	if l == nil {
+		DebugFunc = defaultLogFunc(LevelDebug)
+		InfoFunc = defaultLogFunc(LevelInfo)
+		WarnFunc = defaultLogFunc(LevelWarn)
+		ErrorFunc = defaultLogFunc(LevelError)
+		customized = map[Level]bool{}
+		return
+	}
+	SetDebugFunc(l.Debugf)
+	SetInfoFunc(l.Infof)
+	SetWarnFunc(l.Warnf)
+	SetErrorFunc(l.Errorf)
+}
+
+// funcForLevel returns the current logging function of the given level
+func funcForLevel(level Level) LogFunc {
+	switch level {
+	case LevelDebug:
+		return DebugFunc
+	case LevelInfo:
+		return InfoFunc
+	case LevelWarn:
+		return WarnFunc
+	default:
+		return ErrorFunc
+	}
+}
+
+// Package level logging functions - enhanced for concurrent scenarios
+
+// Debugf logs a debug message with formatting
+func Debugf(format string, args ...interface{}) {
+	DebugFunc(format, args...)
+}
+
+// Infof logs an info message with formatting
+func Infof(format string, args ...interface{}) {
+	InfoFunc(format, args...)
+}
+
+// Warnf logs a warning message with formatting
+func Warnf(format string, args ...interface{}) {
+	WarnFunc(format, args...)
+}
+
+// Errorf logs an error message with formatting
+func Errorf(format string, args ...interface{}) {
+	ErrorFunc(format, args...)
+}
+
+// Debug logs a debug message without formatting
+func Debug(args ...interface{}) {
+	DebugFunc("%s", fmt.Sprint(args...))
+}
+
+// Info logs an info message without formatting
+func Info(args ...interface{}) {
+	InfoFunc("%s", fmt.Sprint(args...))
+}
+
+// Warn logs a warning message without formatting
+func Warn(args ...interface{}) {
+	WarnFunc("%s", fmt.Sprint(args...))
+}
+
+// Error logs an error message without formatting
+func Error(args ...interface{}) {
+	ErrorFunc("%s", fmt.Sprint(args...))
+}
+
+// WithContext creates a contextual logger that includes additional information
+type ContextLogger struct {
+	context string
+	fields  []Field
+}
+
+// NewContextLogger creates a new context logger with the given context string
+// An empty context only prefixes the messages with the fields of the logger
+func NewContextLogger(context string) *ContextLogger {
+	return &ContextLogger{context: context}
+}
+
+// WithField returns a copy of the context logger with an additional field
+// Fields are emitted as JSON keys in JSON format, and as [key=value] in text format
+func (cl *ContextLogger) WithField(key string, value interface{}) *ContextLogger {
+	fields := make([]Field, 0, len(cl.fields)+1)
+	fields = append(fields, cl.fields...)
+	fields = append(fields, Field{Key: key, Value: value})
+	return &ContextLogger{context: cl.context, fields: fields}
+}
+
+// Debugf logs a debug message with context
+func (cl *ContextLogger) Debugf(format string, args ...interface{}) {
+	cl.logf(LevelDebug, format, args...)
+}
+
+// Infof logs an info message with context
+func (cl *ContextLogger) Infof(format string, args ...interface{}) {
+	cl.logf(LevelInfo, format, args...)
+}
+
+// Warnf logs a warning message with context
+func (cl *ContextLogger) Warnf(format string, args ...interface{}) {
+	cl.logf(LevelWarn, format, args...)
+}
+
+// Errorf logs an error message with context
+func (cl *ContextLogger) Errorf(format string, args ...interface{}) {
+	cl.logf(LevelError, format, args...)
+}
+
+// logf writes the message with the context and fields of the logger
+func (cl *ContextLogger) logf(level Level, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+
+	// The default JSON logger keeps the context and fields as structured keys
+	if currentFormat == FormatJSON && !customized[level] {
+		if level >= currentLevel {
+			writeLog(level, cl.context, cl.fields, message)
+		}
+		return
+	}
+
+	var prefix string
+	if cl.context != "" {
+		prefix = "[" + cl.context + "] "
+	}
+	if len(cl.fields) > 0 {
+		pairs := make([]string, 0, len(cl.fields))
+		for _, field := range cl.fields {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", field.Key, field.Value))
+		}
+		prefix += "[" + strings.Join(pairs, " ") + "] "
+	}
+	funcForLevel(level)("%s%s", prefix, message)
+}
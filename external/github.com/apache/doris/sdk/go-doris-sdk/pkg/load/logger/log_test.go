@@ -0,0 +1,164 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureOutput redirects the default logger to a buffer and restores it after the test
+func captureOutput(t *testing.T, format Format) *bytes.Buffer {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	SetFormat(format)
+	t.Cleanup(func() {
+		SetOutput(os.Stdout)
+		SetFormat(FormatText)
+	})
+	return &buf
+}
+
+func TestJSONFormatWithFields(t *testing.T) {
+	buf := captureOutput(t, FormatJSON)
+
+	logger := NewContextLogger("StreamLoad").
+		WithField("label", "test_label").
+		WithField("endpoint", "127.0.0.1:8030")
+	logger.Infof("load %d rows", 10)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("output is not valid JSON: %v, output: %s", err, buf.String())
+	}
+
+	expected := map[string]interface{}{
+		"level":    "INFO",
+		"msg":      "load 10 rows",
+		"label":    "test_label",
+		"endpoint": "127.0.0.1:8030",
+		"context":  "StreamLoad",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, entry[key])
+		}
+	}
+	if _, ok := entry["goroutine"].(float64); !ok {
+		t.Errorf("expected numeric goroutine field, got %v", entry["goroutine"])
+	}
+}
+
+func TestJSONFormatPackageLevel(t *testing.T) {
+	buf := captureOutput(t, FormatJSON)
+
+	Warnf("retry %s", "soon")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("output is not valid JSON: %v, output: %s", err, buf.String())
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "retry soon" {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+}
+
+func TestTextFormatIsDefault(t *testing.T) {
+	buf := captureOutput(t, FormatText)
+
+	NewContextLogger("Worker-1").Infof("hello")
+	NewContextLogger("Worker-2").WithField("label", "l1").Infof("world")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "[INFO ]") || !strings.HasSuffix(lines[0], "[Worker-1] hello") {
+		t.Errorf("unexpected text line: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "[Worker-2] [label=l1] world") {
+		t.Errorf("unexpected text line with fields: %s", lines[1])
+	}
+	if json.Valid([]byte(lines[0])) {
+		t.Errorf("text format should not output JSON: %s", lines[0])
+	}
+}
+
+// capturingLogger records every formatted message with its level
+type capturingLogger struct {
+	lines []string
+}
+
+func (l *capturingLogger) record(level, format string, args ...interface{}) {
+	l.lines = append(l.lines, level+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *capturingLogger) Debugf(format string, args ...interface{}) {
+	l.record("DEBUG", format, args...)
+}
+
+func (l *capturingLogger) Infof(format string, args ...interface{}) {
+	l.record("INFO", format, args...)
+}
+
+func (l *capturingLogger) Warnf(format string, args ...interface{}) {
+	l.record("WARN", format, args...)
+}
+
+func (l *capturingLogger) Errorf(format string, args ...interface{}) {
+	l.record("ERROR", format, args...)
+}
+
+func TestSetLogger(t *testing.T) {
+	buf := captureOutput(t, FormatText)
+	capture := &capturingLogger{}
+	SetLogger(capture)
+	t.Cleanup(func() { SetLogger(nil) })
+
+	Debugf("debug %d", 1)
+	Info("info ", 2)
+	NewContextLogger("StreamLoad").WithField("label", "l1").Warnf("warn %s", "three")
+	Errorf("error")
+
+	expected := []string{
+		"DEBUG debug 1",
+		"INFO info 2",
+		"WARN [StreamLoad] [label=l1] warn three",
+		"ERROR error",
+	}
+	if strings.Join(capture.lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected captured logs: %q", capture.lines)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("default logger should not output anything, got: %s", buf.String())
+	}
+
+	// Restoring the default logger stops routing to the injected one
+	SetLogger(nil)
+	Infof("back to default")
+	if len(capture.lines) != len(expected) {
+		t.Errorf("injected logger should not receive logs after reset: %q", capture.lines)
+	}
+	if !strings.Contains(buf.String(), "back to default") {
+		t.Errorf("default logger should output after reset, got: %s", buf.String())
+	}
+}
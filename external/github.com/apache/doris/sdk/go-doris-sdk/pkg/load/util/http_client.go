@@ -0,0 +1,181 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// maxRedirects is the maximum number of redirects followed by a request, the same as the Go default
+const maxRedirects = 10
+
+// DefaultHTTPTimeout is the total request timeout of the shared HTTP client
+const DefaultHTTPTimeout = 120 * time.Second
+
+var (
+	client *http.Client
+	once   sync.Once
+)
+
+// GetHttpClient returns the HTTP client shared by the clients of the process that have no transport settings
+// NewDefaultHttpClient builds a client with the same settings and its own transport, e.g. for tests
+func GetHttpClient() *http.Client {
+	once.Do(func() {
+		client = NewDefaultHttpClient()
+	})
+	return client
+}
+
+// GetHttpClientWithTimeout returns an HTTP client with the given total request timeout,
+// sharing the connection pool of the shared client. Zero uses DefaultHTTPTimeout
+func GetHttpClientWithTimeout(timeout time.Duration) *http.Client {
+	shared := GetHttpClient()
+	if timeout <= 0 || timeout == shared.Timeout {
+		return shared
+	}
+	httpClient := *shared
+	httpClient.Timeout = timeout
+	return &httpClient
+}
+
+// Connection pool limits of the shared HTTP client
+const (
+	DefaultMaxIdleConnsPerHost = 30 // Maximum idle connections per host for connection reuse to reduce overhead
+	DefaultMaxConnsPerHost     = 50 // Maximum total connections (active + idle) per host, controls concurrency, excess will queue
+	DefaultMaxIdleConns        = 50 // Global maximum idle connections
+)
+
+// DefaultIdleConnTimeout closes the connections idle for longer, so that a recycled FE does not fail the next request
+const DefaultIdleConnTimeout = 90 * time.Second
+
+// PoolOptions are the connection pool limits of an HTTP client, zero values use the defaults
+type PoolOptions struct {
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	MaxIdleConns        int
+	IdleConnTimeout     time.Duration
+}
+
+// HttpOptions are the settings of an HTTP client with its own transport
+type HttpOptions struct {
+	Pool PoolOptions
+	// Certificates are presented to servers requiring mutual TLS
+	Certificates []tls.Certificate
+	// Proxy all requests are sent through, nil sends them directly
+	Proxy *url.URL
+}
+
+// NewHttpClient creates an HTTP client with its own connection pool, zero timeout uses DefaultHTTPTimeout
+func NewHttpClient(options PoolOptions, timeout time.Duration) *http.Client {
+	return NewHttpClientWithOptions(HttpOptions{Pool: options}, timeout)
+}
+
+// NewHttpClientWithOptions creates an HTTP client with its own transport, zero timeout uses DefaultHTTPTimeout
+func NewHttpClientWithOptions(options HttpOptions, timeout time.Duration) *http.Client {
+	pool := options.Pool
+	if pool.MaxIdleConnsPerHost <= 0 {
+		pool.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if pool.MaxConnsPerHost <= 0 {
+		pool.MaxConnsPerHost = DefaultMaxConnsPerHost
+	}
+	if pool.MaxIdleConns <= 0 {
+		pool.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if pool.IdleConnTimeout <= 0 {
+		pool.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	if timeout <= 0 {
+		timeout = DefaultHTTPTimeout
+	}
+
+	transport := &http.Transport{
+		MaxIdleConnsPerHost: pool.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     pool.MaxConnsPerHost,
+		MaxIdleConns:        pool.MaxIdleConns,
+		IdleConnTimeout:     pool.IdleConnTimeout,
+
+		// Wait for "100 Continue" before sending the body, so that a redirect or rejection by FE does not transfer the payload
+		ExpectContinueTimeout: 1 * time.Second,
+
+		// TLS configuration for Doris HTTP endpoints
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true, // Allow insecure connections for Doris HTTP endpoints
+			Certificates:       options.Certificates,
+		},
+	}
+
+	if options.Proxy != nil {
+		transport.Proxy = http.ProxyURL(options.Proxy)
+	}
+
+	return &http.Client{
+		Transport:     transport,
+		Timeout:       timeout, // Total request timeout
+		CheckRedirect: checkRedirect,
+	}
+}
+
+// NewDefaultHttpClient creates an HTTP client with the default settings of the shared client and its own transport
+func NewDefaultHttpClient() *http.Client {
+	return NewHttpClient(PoolOptions{}, DefaultHTTPTimeout)
+}
+
+// forwardsCredentials reports whether a redirected request keeps the credentials: it is redirected by the
+// endpoint, e.g. FE to a BE node, or back to the endpoint, and not downgraded from https to http
+func forwardsCredentials(req *http.Request, via []*http.Request) bool {
+	endpoint, from := via[0].URL, via[len(via)-1].URL
+	if req.URL.Scheme != "https" && (endpoint.Scheme == "https" || from.Scheme == "https") {
+		return false
+	}
+	return from.Host == endpoint.Host || req.URL.Host == endpoint.Host
+}
+
+// redirectHookKey is the context key of the hook called on redirects
+type redirectHookKey struct{}
+
+// WithRedirectHook returns a context making the clients of this package call hook with the hosts a request is
+// redirected from and to, e.g. when FE redirects a stream load to a BE node
+func WithRedirectHook(ctx context.Context, hook func(from, to string)) context.Context {
+	return context.WithValue(ctx, redirectHookKey{}, hook)
+}
+
+// checkRedirect keeps the credentials when FE redirects a stream load to a BE node
+// Go strips the Authorization header on redirects to another host, which would make the load fail on BE, like
+// curl --location-trusted recommended by the Doris documentation they are kept, but only on redirects by or to
+// the endpoint the request was sent to and never from https to http
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if auth := via[0].Header.Get("Authorization"); auth != "" && forwardsCredentials(req, via) {
+		req.Header.Set("Authorization", auth)
+	} else {
+		req.Header.Del("Authorization")
+	}
+	if hook, ok := req.Context().Value(redirectHookKey{}).(func(from, to string)); ok {
+		hook(via[len(via)-1].URL.Host, req.URL.Host)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RedactedValue replaces sensitive values in logs
+const RedactedValue = "***"
+
+// Substrings of header and option keys whose values must never be logged
+var sensitiveKeyPatterns = []string{
+	"authorization",
+	"password",
+	"passwd",
+	"token",
+	"secret",
+}
+
+// IsSensitiveKey reports whether the value of the given key must be masked in logs
+func IsSensitiveKey(key string) bool {
+	keyLower := strings.ToLower(key)
+	for _, pattern := range sensitiveKeyPatterns {
+		if strings.Contains(keyLower, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact masks a sensitive value, empty values are kept to show they are unset
+func Redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedValue
+}
+
+// RedactMap returns a copy of the map with the values of sensitive keys masked
+func RedactMap(m map[string]string) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		if IsSensitiveKey(k) {
+			v = Redact(v)
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// RedactHeader returns a printable copy of the headers with the values of sensitive keys masked
+func RedactHeader(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for k, v := range header {
+		value := strings.Join(v, ",")
+		if IsSensitiveKey(k) {
+			value = Redact(value)
+		}
+		result[k] = value
+	}
+	return result
+}
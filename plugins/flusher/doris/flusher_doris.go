@@ -17,7 +17,11 @@ package doris
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -36,7 +40,7 @@ import (
 // the performance of data loading into Doris.
 type FlusherDoris struct {
 	// Basic connection configuration
-	Addresses []string // List of Doris FE addresses in format "host:port"
+	Addresses []string // List of Doris FE addresses in format "http://host:port"
 	Database  string   // Target Doris database name
 	// Authentication related configuration
 	Authentication Authentication
@@ -45,18 +49,77 @@ type FlusherDoris struct {
 	LoadProperties map[string]string // Additional Stream Load properties to set in header
 	// Progress log interval in seconds, default 10s, set to 0 to disable
 	LogProgressInterval int
+	// LogProgressEveryNLoads also logs the lifetime totals after every N successful loads, independent of the interval,
+	// default 0 disables it
+	LogProgressEveryNLoads int
 	// Group commit mode: "sync", "async", or "off" (default: "off")
 	GroupCommit string
 	// Concurrency controls how many goroutines are used to send data concurrently
 	Concurrency int
 	// QueueCapacity controls the capacity of the task queue
 	QueueCapacity int
+	// MaxBufferedBytes caps the size of the LogGroups waiting in the task queue, zero only limits QueueCapacity
+	MaxBufferedBytes int64
+	// OverflowPolicy controls what happens when the task queue is full: "block" (default) waits for space,
+	// "drop_oldest" drops the oldest waiting data and "drop_newest" drops the data being flushed
+	OverflowPolicy string
+	// StopDrainTimeoutSeconds is how long Stop waits for the queued data to be loaded in async mode before canceling
+	// the loads in flight, so that a hung Doris does not block Stop for the whole retry budget. Default 30
+	StopDrainTimeoutSeconds int
+	// LoadGranularity controls how the LogGroups of a Flush are loaded: "per_flush" (default) merges them into a single
+	// load, "per_group" sends one load per LogGroup. A failing load fails the Flush, so the LogGroups loaded before
+	// it are loaded again when the pipeline retries the Flush
+	LoadGranularity string
+	// Connection pool limits of this flusher, when any is set the flusher gets its own pool instead of
+	// sharing one with the other flusher_doris instances of the process, zero values use the SDK defaults
+	MaxConnsPerHost     int
+	MaxIdleConnsPerHost int
+	MaxIdleConns        int
+	// TimeColumn is the column the log time is written into, empty disables it
+	TimeColumn string
+	// TimeUnit of the time column: "seconds" (default) or "millis"
+	TimeUnit string
+	// DeleteSignField is the log field telling whether a record deletes its row of a unique key table, e.g. "op"
+	// Every record then gets the __DORIS_DELETE_SIGN__ column, 1 when the field has one of DeleteSignValues
+	DeleteSignField string
+	// DeleteSignValues are the values of DeleteSignField deleting the row, default ["delete"]
+	DeleteSignValues []string
+	// ConstantColumns set columns missing from the logs to the same value in every row, e.g. {"env": "prod"}
+	// They are appended to the columns header as env='prod', so LoadProperties must set an explicit columns list
+	ConstantColumns map[string]string
+	// ConverterErrorPolicy controls LogGroups failing conversion: "skip" (default) drops them, "fail" fails the
+	// flush so that the pipeline retries it, "deadletter" appends them to DeadLetterPath and continues
+	// "fail" requires the synchronous mode, async workers convert after Flush has returned
+	ConverterErrorPolicy string
+	// DeadLetterPath is the file LogGroups failing conversion are appended to as JSON lines under the deadletter policy
+	DeadLetterPath string
+	// LabelTemplate builds the label prefix of each load from the {project}, {logstore} and {config} of the flushed
+	// data, characters not allowed in Doris labels are replaced by "_". It is ignored under group commit
+	LabelTemplate string
+	// TagFieldsRename renames tag fields, merged into Convert.TagFieldsRename and taking precedence over it
+	TagFieldsRename map[string]string
+	// ProtocolFieldsRename renames protocol fields, the keys can only be: contents, tags, time.
+	// Merged into Convert.ProtocolFieldsRename and taking precedence over it
+	ProtocolFieldsRename map[string]string
+	// Serializer replaces the converter when set, it can only be set programmatically. Its output of each LogGroup
+	// is sent as is, so it must end records itself, and TimeColumn is ignored
+	Serializer RecordSerializer
+	// RecordTransform is applied to the content fields of each log before serialization, it can only be set
+	// programmatically. It returns the fields to load, e.g. with fields added or removed, or nil to drop the log
+	// It is called concurrently when Concurrency is greater than 1
+	RecordTransform func(fields map[string]string) map[string]string
 
 	dorisClient *load.DorisLoadClient
 	context     pipeline.Context
 	converter   *converter.Converter
 	Convert     convertConfig
 
+	// JSON encoded TimeColumn, nil when disabled
+	timeColumnKey []byte
+
+	// Serializes writes to the dead letter file
+	deadLetterMu sync.Mutex
+
 	// Statistics for progress logging
 	stats          *statistics
 	progressTicker *time.Ticker
@@ -67,9 +130,17 @@ type FlusherDoris struct {
 	bufferPool sync.Pool
 
 	// Async task queue for concurrent flushing
-	queue     chan []*protocol.LogGroup
-	counter   sync.WaitGroup
-	workersWg sync.WaitGroup // Separate WaitGroup for async workers
+	queue   chan flushTask
+	counter sync.WaitGroup
+	// Guards queuedBytes, bufferCond is signaled when a task leaves the queue
+	bufferMu    sync.Mutex
+	bufferCond  *sync.Cond
+	queuedBytes int64
+	workersWg   sync.WaitGroup // Separate WaitGroup for async workers
+
+	// Context of the loads, cancelled by Stop so that loads blocked on Doris do not hold up the pipeline
+	loadCtx     context.Context
+	cancelLoads context.CancelFunc
 
 	// Ensure Stop() is only called once
 	stopOnce sync.Once
@@ -80,6 +151,9 @@ type statistics struct {
 	startTime       time.Time
 	totalBytes      uint64 // atomic
 	totalRows       uint64 // atomic
+	droppedGroups   uint64 // atomic, LogGroups dropped by the overflow policy
+	failedLoads     uint64 // atomic, loads that failed
+	succeededLoads  uint64 // atomic, loads that succeeded
 	lastBytes       uint64 // atomic
 	lastRows        uint64 // atomic
 	lastReportTime  time.Time
@@ -88,6 +162,20 @@ type statistics struct {
 	mu              sync.Mutex
 }
 
+// FlusherStats is a snapshot of the running statistics of the flusher
+type FlusherStats struct {
+	TotalBytes uint64
+	TotalRows  uint64
+	// Average speed since the flusher was created
+	AverageMBps          float64
+	AverageRowsPerSecond float64
+	// Speed since the last progress report, or since the flusher was created before the first report
+	CurrentMBps          float64
+	CurrentRowsPerSecond float64
+	FailedLoads          uint64 // Loads that failed, including the ones retried later by the pipeline
+	DroppedGroups        uint64 // LogGroups dropped by the overflow policy
+}
+
 type convertConfig struct {
 	// Rename one or more fields from tags
 	TagFieldsRename map[string]string
@@ -99,9 +187,55 @@ type convertConfig struct {
 	Encoding string
 }
 
+const (
+	timeUnitSeconds = "seconds"
+	timeUnitMillis  = "millis"
+
+	converterErrorSkip       = "skip"
+	converterErrorFail       = "fail"
+	converterErrorDeadLetter = "deadletter"
+
+	loadPerFlush = "per_flush"
+	loadPerGroup = "per_group"
+
+	overflowBlock      = "block"
+	overflowDropOldest = "drop_oldest"
+	overflowDropNewest = "drop_newest"
+)
+
+// protocolFields are the protocol fields ProtocolFieldsRename may rename
+var protocolFields = []string{"contents", "tags", "time"}
+
+// deadLetterRecord is a line of the dead letter file
+type deadLetterRecord struct {
+	Time     string             `json:"time"`
+	Error    string             `json:"error"`
+	LogGroup *protocol.LogGroup `json:"logGroup"`
+}
+
+// RecordSerializer serializes the logs of a LogGroup into the data loaded into Doris
+type RecordSerializer interface {
+	Serialize(logGroup *protocol.LogGroup) ([]byte, error)
+}
+
+// FormattedRecordSerializer is a RecordSerializer whose output is not JSON lines, e.g. CSV,
+// Format tells Doris how to parse it
+type FormattedRecordSerializer interface {
+	RecordSerializer
+	Format() load.Format
+}
+
+// flushTask is the data of a Flush call waiting in the async queue
+type flushTask struct {
+	labelPrefix  string
+	logGroupList []*protocol.LogGroup
+	size         int64 // Serialized size of the LogGroups, counted against MaxBufferedBytes
+}
+
 type FlusherFunc func(projectName string, logstoreName string, configName string, logGroupList []*protocol.LogGroup) error
 
 func NewFlusherDoris() *FlusherDoris {
+	loadCtx, cancelLoads := context.WithCancel(context.Background())
 	return &FlusherDoris{
 		Addresses: []string{},
 		Authentication: Authentication{
@@ -111,11 +245,12 @@ func NewFlusherDoris() *FlusherDoris {
 				Database: "",
 			},
 		},
-		Table:               "",
-		LogProgressInterval: 10,    // Default 10 seconds
-		GroupCommit:         "off", // Default: disable group commit
-		Concurrency:         1,     // Default: synchronous (no concurrency)
-		QueueCapacity:       1024,  // Default queue capacity
+		Table:                   "",
+		LogProgressInterval:     10,    // Default 10 seconds
+		GroupCommit:             "off", // Default: disable group commit
+		Concurrency:             1,     // Default: synchronous (no concurrency)
+		QueueCapacity:           1024,  // Default queue capacity
+		StopDrainTimeoutSeconds: 30,    // Default 30 seconds
 		Convert: convertConfig{
 			Protocol: converter.ProtocolCustomSingle,
 			Encoding: converter.EncodingJSON,
@@ -123,7 +258,9 @@ func NewFlusherDoris() *FlusherDoris {
 		stats: &statistics{
 			startTime: time.Now(),
 		},
-		stopChan: make(chan struct{}),
+		stopChan:    make(chan struct{}),
+		loadCtx:     loadCtx,
+		cancelLoads: cancelLoads,
 		bufferPool: sync.Pool{
 			New: func() interface{} {
 				// Pre-allocate buffer with reasonable initial capacity
@@ -150,6 +287,8 @@ func (f *FlusherDoris) Init(context pipeline.Context) error {
 	if f.Convert.Protocol == "" {
 		f.Convert.Protocol = converter.ProtocolCustomSingle
 	}
+	f.Convert.TagFieldsRename = mergeRenames(f.Convert.TagFieldsRename, f.TagFieldsRename)
+	f.Convert.ProtocolFieldsRename = mergeRenames(f.Convert.ProtocolFieldsRename, f.ProtocolFieldsRename)
 	// Init converter
 	convert, err := f.getConverter()
 	if err != nil {
@@ -158,6 +297,28 @@ func (f *FlusherDoris) Init(context pipeline.Context) error {
 	}
 	f.converter = convert
 
+	if f.Serializer != nil && f.TimeColumn != "" {
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM",
+			"doris time column is ignored because a serializer is set", "timeColumn", f.TimeColumn)
+		f.TimeColumn = ""
+	}
+	if f.Serializer != nil && f.DeleteSignField != "" {
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM",
+			"doris delete sign field is ignored because a serializer is set", "deleteSignField", f.DeleteSignField)
+		f.DeleteSignField = ""
+	}
+	if f.TimeColumn != "" {
+		f.timeColumnKey, _ = json.Marshal(f.TimeColumn)
+	}
+	if f.DeleteSignField != "" && len(f.DeleteSignValues) == 0 {
+		f.DeleteSignValues = []string{"delete"}
+	}
+	if f.LabelTemplate != "" && parseGroupCommitMode(f.GroupCommit) != load.OFF {
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM",
+			"doris label template is ignored because group commit does not allow labels", "groupCommit", f.GroupCommit)
+		f.LabelTemplate = ""
+	}
+
 	// Init Doris client
 	if err := f.initDorisClient(); err != nil {
 		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris client fail, error", err)
@@ -169,7 +330,8 @@ func (f *FlusherDoris) Init(context pipeline.Context) error {
 		if f.QueueCapacity <= 0 {
 			f.QueueCapacity = 1024
 		}
-		f.queue = make(chan []*protocol.LogGroup, f.QueueCapacity)
+		f.queue = make(chan flushTask, f.QueueCapacity)
+		f.bufferCond = sync.NewCond(&f.bufferMu)
 
 		// Start worker goroutines
 		for i := 0; i < f.Concurrency; i++ {
@@ -178,7 +340,8 @@ func (f *FlusherDoris) Init(context pipeline.Context) error {
 		}
 
 		logger.Info(f.context.GetRuntimeContext(), "Doris flusher async mode enabled",
-			"concurrency", f.Concurrency, "queueCapacity", f.QueueCapacity)
+			"concurrency", f.Concurrency, "queueCapacity", f.QueueCapacity,
+			"maxBufferedBytes", f.MaxBufferedBytes, "overflowPolicy", f.OverflowPolicy)
 	}
 
 	// Start progress logging if enabled
@@ -195,6 +358,21 @@ func (f *FlusherDoris) getConverter() (*converter.Converter, error) {
 	return converter.NewConverter(f.Convert.Protocol, f.Convert.Encoding, f.Convert.TagFieldsRename, f.Convert.ProtocolFieldsRename, f.context.GetPipelineScopeConfig())
 }
 
+// mergeRenames adds the renames of override to base, override wins for fields renamed by both
+func mergeRenames(base, override map[string]string) map[string]string {
+	if len(override) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for field, name := range base {
+		merged[field] = name
+	}
+	for field, name := range override {
+		merged[field] = name
+	}
+	return merged
+}
+
 func (f *FlusherDoris) Description() string {
 	return "Doris flusher for logtail"
 }
@@ -223,18 +401,7 @@ func (f *FlusherDoris) initDorisClient() error {
 	}
 
 	// Create Doris SDK configuration
-	config := &load.Config{
-		Endpoints:   f.Addresses,
-		User:        username,
-		Password:    password,
-		Database:    f.Database,
-		Table:       f.Table,
-		Format:      load.DefaultJSONFormat(),
-		Retry:       load.DefaultRetry(),
-		GroupCommit: parseGroupCommitMode(f.GroupCommit),
-		LabelPrefix: "LoongCollector_doris_flusher",
-		Options:     f.LoadProperties,
-	}
+	config := f.buildLoadConfig(username, password)
 
 	// Create Doris client
 	client, err := load.NewLoadClient(config)
@@ -249,6 +416,55 @@ func (f *FlusherDoris) initDorisClient() error {
 	return nil
 }
 
+// buildLoadConfig creates the Doris SDK configuration of the flusher
+func (f *FlusherDoris) buildLoadConfig(username, password string) *load.Config {
+	config := &load.Config{
+		Endpoints:       f.Addresses,
+		User:            username,
+		Password:        password,
+		Database:        f.Database,
+		Table:           f.Table,
+		Format:          load.DefaultJSONFormat(),
+		Retry:           load.DefaultRetry(),
+		GroupCommit:     parseGroupCommitMode(f.GroupCommit),
+		LabelPrefix:     "LoongCollector_doris_flusher",
+		Options:         f.loadOptions(),
+		DeleteSign:      f.DeleteSignField != "",
+		ConstantColumns: f.ConstantColumns,
+	}
+	if serializer, ok := f.Serializer.(FormattedRecordSerializer); ok {
+		config.Format = serializer.Format()
+	}
+	if f.MaxConnsPerHost > 0 || f.MaxIdleConnsPerHost > 0 || f.MaxIdleConns > 0 {
+		config.ConnectionPool = &load.ConnectionPool{
+			MaxConnsPerHost:     f.MaxConnsPerHost,
+			MaxIdleConnsPerHost: f.MaxIdleConnsPerHost,
+			MaxIdleConns:        f.MaxIdleConns,
+		}
+	}
+	return config
+}
+
+// loadOptions returns the Stream Load properties, adding the time column to an explicit columns list
+func (f *FlusherDoris) loadOptions() map[string]string {
+	columns, ok := f.LoadProperties["columns"]
+	if f.TimeColumn == "" || !ok {
+		return f.LoadProperties
+	}
+	for _, column := range strings.Split(columns, ",") {
+		if strings.TrimSpace(column) == f.TimeColumn {
+			return f.LoadProperties
+		}
+	}
+
+	options := make(map[string]string, len(f.LoadProperties))
+	for k, v := range f.LoadProperties {
+		options[k] = v
+	}
+	options["columns"] = columns + "," + f.TimeColumn
+	return options
+}
+
 func (f *FlusherDoris) Validate() error {
 	if len(f.Addresses) == 0 {
 		var err = fmt.Errorf("doris addrs is nil")
@@ -260,9 +476,84 @@ func (f *FlusherDoris) Validate() error {
 		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
 		return err
 	}
+	if f.TimeUnit != "" && f.TimeUnit != timeUnitSeconds && f.TimeUnit != timeUnitMillis {
+		var err = fmt.Errorf("doris time unit must be %s or %s, got %s", timeUnitSeconds, timeUnitMillis, f.TimeUnit)
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+		return err
+	}
+	if f.LogProgressEveryNLoads < 0 {
+		var err = fmt.Errorf("doris log progress every n loads cannot be negative, got %d", f.LogProgressEveryNLoads)
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+		return err
+	}
+	if f.StopDrainTimeoutSeconds < 0 {
+		var err = fmt.Errorf("doris stop drain timeout seconds cannot be negative, got %d", f.StopDrainTimeoutSeconds)
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+		return err
+	}
+	if f.MaxBufferedBytes < 0 {
+		var err = fmt.Errorf("doris max buffered bytes cannot be negative, got %d", f.MaxBufferedBytes)
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+		return err
+	}
+	switch f.OverflowPolicy {
+	case "", overflowBlock, overflowDropOldest, overflowDropNewest:
+	default:
+		var err = fmt.Errorf("doris overflow policy must be %s, %s or %s, got %s",
+			overflowBlock, overflowDropOldest, overflowDropNewest, f.OverflowPolicy)
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+		return err
+	}
+	switch f.LoadGranularity {
+	case "", loadPerFlush, loadPerGroup:
+	default:
+		var err = fmt.Errorf("doris load granularity must be %s or %s, got %s", loadPerFlush, loadPerGroup, f.LoadGranularity)
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+		return err
+	}
+	switch f.ConverterErrorPolicy {
+	case "", converterErrorSkip:
+	case converterErrorFail:
+		if f.Concurrency > 1 {
+			var err = fmt.Errorf("doris %s converter error policy cannot be used with concurrency %d, "+
+				"async workers convert after Flush has returned", converterErrorFail, f.Concurrency)
+			logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+			return err
+		}
+	case converterErrorDeadLetter:
+		if f.DeadLetterPath == "" {
+			var err = fmt.Errorf("doris dead letter path is required by the %s converter error policy", converterErrorDeadLetter)
+			logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+			return err
+		}
+	default:
+		var err = fmt.Errorf("doris converter error policy must be %s, %s or %s, got %s",
+			converterErrorSkip, converterErrorFail, converterErrorDeadLetter, f.ConverterErrorPolicy)
+		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+		return err
+	}
+	for _, renames := range []map[string]string{f.ProtocolFieldsRename, f.Convert.ProtocolFieldsRename} {
+		for field := range renames {
+			if !isProtocolField(field) {
+				var err = fmt.Errorf("doris protocol field to rename must be one of %s, got %s", strings.Join(protocolFields, ", "), field)
+				logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_INIT_ALARM", "init doris flusher error", err)
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// isProtocolField reports whether field is a protocol field that can be renamed
+func isProtocolField(field string) bool {
+	for _, protocolField := range protocolFields {
+		if field == protocolField {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *FlusherDoris) Flush(projectName string, logstoreName string, configName string, logGroupList []*protocol.LogGroup) error {
 	if f.dorisClient == nil {
 		return fmt.Errorf("doris client not initialized")
@@ -272,46 +563,111 @@ func (f *FlusherDoris) Flush(projectName string, logstoreName string, configName
 		return nil
 	}
 
+	task := flushTask{
+		labelPrefix:  f.labelPrefix(projectName, logstoreName, configName),
+		logGroupList: logGroupList,
+	}
+
 	// Async mode: add task to queue and return immediately
 	if f.Concurrency > 1 {
-		return f.addTask(logGroupList)
+		return f.addTask(task)
 	}
 
 	// Sync mode: process immediately
-	return f.flushSync(logGroupList)
+	return f.flushSync(task)
+}
+
+// labelPrefix renders LabelTemplate for the flushed data, empty when no template is set
+func (f *FlusherDoris) labelPrefix(projectName, logstoreName, configName string) string {
+	if f.LabelTemplate == "" {
+		return ""
+	}
+	// The SDK replaces the characters not allowed in Doris labels and truncates the label to its maximum length
+	return strings.NewReplacer(
+		"{project}", projectName,
+		"{logstore}", logstoreName,
+		"{config}", configName,
+	).Replace(f.LabelTemplate)
 }
 
 // addTask adds a flush task to the queue for async processing
-// This method will BLOCK if the queue is full, ensuring NO DATA LOSS
-func (f *FlusherDoris) addTask(logGroupList []*protocol.LogGroup) error {
+// When the queue is full or holds MaxBufferedBytes, OverflowPolicy decides between blocking, which creates
+// backpressure to upstream components and never loses data, and dropping the oldest or the newest data
+func (f *FlusherDoris) addTask(task flushTask) error {
 	f.counter.Add(1)
+	for _, logGroup := range task.logGroupList {
+		task.size += int64(logGroup.Size())
+	}
 
-	// First, try non-blocking send to detect queue congestion
-	select {
-	case f.queue <- logGroupList:
-		// Successfully sent without blocking
-		return nil
-	default:
-		// Queue is full, log warning and then block
-		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_QUEUE_FULL",
-			"doris flusher queue is full, blocking until space available",
-			"queueCapacity", f.QueueCapacity,
-			"concurrency", f.Concurrency,
-			"suggestion", "consider increasing Concurrency or QueueCapacity")
-
-		// Now block until queue has space - NEVER drop data
-		// This creates backpressure to upstream components when system is overloaded
-		f.queue <- logGroupList
-		return nil
+	f.bufferMu.Lock()
+	defer f.bufferMu.Unlock()
+	warned := false
+	for !f.hasRoom(task.size) {
+		switch f.OverflowPolicy {
+		case overflowDropNewest:
+			f.dropTask(task, overflowDropNewest)
+			return nil
+		case overflowDropOldest:
+			select {
+			case oldest := <-f.queue:
+				f.queuedBytes -= oldest.size
+				f.dropTask(oldest, overflowDropOldest)
+			default:
+				// The workers took the remaining tasks meanwhile, wait for them to release their bytes
+				f.bufferCond.Wait()
+			}
+		default:
+			if !warned {
+				logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_QUEUE_FULL",
+					"doris flusher queue is full, blocking until space available",
+					"queueCapacity", f.QueueCapacity,
+					"queuedBytes", f.queuedBytes,
+					"concurrency", f.Concurrency,
+					"suggestion", "consider increasing Concurrency or QueueCapacity")
+				warned = true
+			}
+			f.bufferCond.Wait()
+		}
 	}
+
+	// Only producers holding bufferMu add to the queue, so there is room for the task
+	f.queuedBytes += task.size
+	f.queue <- task
+	return nil
+}
+
+// hasRoom reports whether a task of the given size fits in the queue, a single task larger than
+// MaxBufferedBytes is accepted into an empty queue. It must be called with bufferMu held
+func (f *FlusherDoris) hasRoom(size int64) bool {
+	if len(f.queue) >= cap(f.queue) {
+		return false
+	}
+	return f.MaxBufferedBytes <= 0 || f.queuedBytes == 0 || f.queuedBytes+size <= f.MaxBufferedBytes
+}
+
+// dropTask discards a task under the given overflow policy. It must be called with bufferMu held
+func (f *FlusherDoris) dropTask(task flushTask, policy string) {
+	dropped := atomic.AddUint64(&f.stats.droppedGroups, uint64(len(task.logGroupList)))
+	logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_QUEUE_FULL",
+		"doris flusher queue is full, dropping data",
+		"policy", policy,
+		"logGroups", len(task.logGroupList),
+		"bytes", task.size,
+		"totalDroppedLogGroups", dropped)
+	f.counter.Done()
 }
 
 // runFlushWorker is the worker goroutine that processes flush tasks from the queue
 func (f *FlusherDoris) runFlushWorker() {
 	defer f.workersWg.Done()
 
-	for logGroupList := range f.queue {
-		err := f.flushSync(logGroupList)
+	for task := range f.queue {
+		f.bufferMu.Lock()
+		f.queuedBytes -= task.size
+		f.bufferCond.Broadcast()
+		f.bufferMu.Unlock()
+
+		err := f.flushSync(task)
 		if err != nil {
 			logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_FLUSH_ALARM",
 				"worker failed to flush data to doris, error", err)
@@ -321,7 +677,16 @@ func (f *FlusherDoris) runFlushWorker() {
 }
 
 // flushSync performs synchronous flush operation
-func (f *FlusherDoris) flushSync(logGroupList []*protocol.LogGroup) error {
+func (f *FlusherDoris) flushSync(task flushTask) error {
+	if f.LoadGranularity == loadPerGroup && len(task.logGroupList) > 1 {
+		for _, logGroup := range task.logGroupList {
+			if err := f.flushSync(flushTask{labelPrefix: task.labelPrefix, logGroupList: []*protocol.LogGroup{logGroup}}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// Get buffer from pool to reduce allocations
 	buffer := f.bufferPool.Get().(*bytes.Buffer)
 	buffer.Reset() // Reset buffer for reuse
@@ -339,19 +704,45 @@ func (f *FlusherDoris) flushSync(logGroupList []*protocol.LogGroup) error {
 	totalLogCount := 0
 
 	// Merge all LogGroups into a single batch
-	for _, logGroup := range logGroupList {
+	for _, logGroup := range task.logGroupList {
 		logger.Debug(f.context.GetRuntimeContext(), "[LogGroup] topic", logGroup.Topic, "logstore", logGroup.Category, "logcount", len(logGroup.Logs), "tags", logGroup.LogTags)
 
+		if f.RecordTransform != nil {
+			if logGroup = f.transformLogGroup(logGroup); len(logGroup.Logs) == 0 {
+				continue
+			}
+		}
+
+		if f.Serializer != nil {
+			data, err := f.Serializer.Serialize(logGroup)
+			if err != nil {
+				if err = f.handleConvertError(logGroup, err); err != nil {
+					return err
+				}
+				continue
+			}
+			buffer.Write(data)
+			totalLogCount += len(logGroup.Logs)
+			continue
+		}
+
 		// Convert log group to byte stream
 		serializedLogs, err := f.converter.ToByteStream(logGroup)
 		if err != nil {
-			logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_FLUSH_ALARM", "flush doris convert log fail, error", err)
+			if err = f.handleConvertError(logGroup, err); err != nil {
+				return err
+			}
 			continue
 		}
 
 		// Append all logs to the same buffer
-		for _, log := range serializedLogs.([][]byte) {
-			buffer.Write(log)
+		for i, log := range serializedLogs.([][]byte) {
+			if (f.timeColumnKey != nil || f.DeleteSignField != "") && i < len(logGroup.Logs) {
+				f.writeWithExtraColumns(buffer, log, logGroup.Logs[i])
+			} else {
+				// Converters terminating their lines already, e.g. influxdb, would add empty rows
+				buffer.Write(bytes.TrimRight(log, "\n"))
+			}
 			buffer.WriteByte('\n') // Add newline separator for JSON object line format
 			totalLogCount++
 		}
@@ -367,9 +758,22 @@ func (f *FlusherDoris) flushSync(logGroupList []*protocol.LogGroup) error {
 	dataToLoad := buffer.Bytes()
 	reader := bytes.NewReader(dataToLoad)
 
-	response, err := f.dorisClient.Load(reader)
+	// Doris is expected to count one row per log, unless a custom serializer decides the rows
+	ctx := f.loadCtx
+	if f.Serializer == nil {
+		ctx = load.WithExpectedRows(ctx, int64(totalLogCount))
+	}
+
+	var response *load.LoadResponse
+	var err error
+	if task.labelPrefix != "" {
+		response, err = f.dorisClient.LoadWithLabelPrefixContext(ctx, task.labelPrefix, reader)
+	} else {
+		response, err = f.dorisClient.LoadContext(ctx, reader)
+	}
 
 	if err != nil {
+		atomic.AddUint64(&f.stats.failedLoads, 1)
 		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_FLUSH_ALARM", "flush doris load fail, error", err)
 		return fmt.Errorf("failed to load data to doris: %w", err)
 	}
@@ -384,6 +788,7 @@ func (f *FlusherDoris) flushSync(logGroupList []*protocol.LogGroup) error {
 		// Update statistics
 		f.updateStatistics(uint64(response.Resp.LoadBytes), uint64(response.Resp.NumberLoadedRows))
 	} else {
+		atomic.AddUint64(&f.stats.failedLoads, 1)
 		logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_FLUSH_ALARM",
 			"doris load failed with status", response.Status,
 			"message", response.ErrorMessage)
@@ -393,15 +798,164 @@ func (f *FlusherDoris) flushSync(logGroupList []*protocol.LogGroup) error {
 	return nil
 }
 
+// handleConvertError applies the ConverterErrorPolicy to a LogGroup failing conversion,
+// it returns an error when the flush must fail
+func (f *FlusherDoris) handleConvertError(logGroup *protocol.LogGroup, convertErr error) error {
+	logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_FLUSH_ALARM", "flush doris convert log fail, error", convertErr,
+		"policy", f.ConverterErrorPolicy)
+	switch f.ConverterErrorPolicy {
+	case converterErrorFail:
+		return fmt.Errorf("failed to convert log group: %w", convertErr)
+	case converterErrorDeadLetter:
+		if err := f.writeDeadLetter(logGroup, convertErr); err != nil {
+			logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_FLUSH_ALARM", "write doris dead letter fail, error", err)
+		}
+	}
+	return nil
+}
+
+// writeDeadLetter appends a LogGroup failing conversion to the dead letter file
+func (f *FlusherDoris) writeDeadLetter(logGroup *protocol.LogGroup, convertErr error) error {
+	line, err := json.Marshal(deadLetterRecord{
+		Time:     time.Now().Format(time.RFC3339),
+		Error:    convertErr.Error(),
+		LogGroup: logGroup,
+	})
+	if err != nil {
+		return err
+	}
+
+	f.deadLetterMu.Lock()
+	defer f.deadLetterMu.Unlock()
+	file, err := os.OpenFile(f.DeadLetterPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// transformLogGroup returns a copy of the LogGroup with RecordTransform applied to its logs, without the dropped ones
+func (f *FlusherDoris) transformLogGroup(logGroup *protocol.LogGroup) *protocol.LogGroup {
+	transformed := *logGroup
+	transformed.Logs = make([]*protocol.Log, 0, len(logGroup.Logs))
+	for _, log := range logGroup.Logs {
+		fields := make(map[string]string, len(log.Contents))
+		for _, content := range log.Contents {
+			fields[content.Key] = content.Value
+		}
+		if fields = f.RecordTransform(fields); fields == nil {
+			continue
+		}
+		transformedLog := *log
+		transformedLog.Contents = transformContents(log.Contents, fields)
+		transformed.Logs = append(transformed.Logs, &transformedLog)
+	}
+	return &transformed
+}
+
+// transformContents returns the transformed fields as log contents, the fields kept in their original order
+// followed by the added fields sorted by key
+func transformContents(contents []*protocol.Log_Content, fields map[string]string) []*protocol.Log_Content {
+	result := make([]*protocol.Log_Content, 0, len(fields))
+	kept := make(map[string]bool, len(contents))
+	for _, content := range contents {
+		if value, ok := fields[content.Key]; ok && !kept[content.Key] {
+			kept[content.Key] = true
+			result = append(result, &protocol.Log_Content{Key: content.Key, Value: value})
+		}
+	}
+	added := make([]string, 0, len(fields)-len(kept))
+	for key := range fields {
+		if !kept[key] {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	for _, key := range added {
+		result = append(result, &protocol.Log_Content{Key: key, Value: fields[key]})
+	}
+	return result
+}
+
+// writeWithExtraColumns writes the serialized JSON object of a log with the enabled time and delete sign columns added
+func (f *FlusherDoris) writeWithExtraColumns(buffer *bytes.Buffer, record []byte, log *protocol.Log) {
+	record = bytes.TrimRight(record, " \t\r\n")
+	end := len(record) - 1
+	if end < 0 || record[end] != '}' {
+		// Not a JSON object, leave it as it is
+		buffer.Write(record)
+		return
+	}
+
+	buffer.Write(record[:end])
+	separate := len(bytes.TrimSpace(record[:end])) > 1
+	if f.timeColumnKey != nil {
+		if separate {
+			buffer.WriteByte(',')
+		}
+		buffer.Write(f.timeColumnKey)
+		buffer.WriteByte(':')
+		buffer.WriteString(strconv.FormatUint(f.logTime(log), 10))
+		separate = true
+	}
+	if f.DeleteSignField != "" {
+		if separate {
+			buffer.WriteByte(',')
+		}
+		buffer.WriteString(`"` + load.DeleteSignColumn + `":`)
+		if f.deletesRow(log) {
+			buffer.WriteByte('1')
+		} else {
+			buffer.WriteByte('0')
+		}
+	}
+	buffer.WriteByte('}')
+}
+
+// deletesRow reports whether the DeleteSignField of the log has one of the DeleteSignValues
+func (f *FlusherDoris) deletesRow(log *protocol.Log) bool {
+	for _, content := range log.Contents {
+		if content.Key != f.DeleteSignField {
+			continue
+		}
+		for _, value := range f.DeleteSignValues {
+			if content.Value == value {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// logTime returns the time of the log in the configured unit
+func (f *FlusherDoris) logTime(log *protocol.Log) uint64 {
+	if f.TimeUnit == timeUnitMillis {
+		return uint64(log.GetTime())*1000 + uint64(log.GetTimeNs())/1000000
+	}
+	return uint64(log.GetTime())
+}
+
 func (f *FlusherDoris) IsReady(projectName string, logstoreName string, logstoreKey int64) bool {
 	return f.dorisClient != nil
 }
 
 func (f *FlusherDoris) SetUrgent(flag bool) {}
 
+// Stop cancels a synchronous load in flight, which fails instead of waiting for Doris,
+// and waits for the async workers to flush the queued tasks and exit
 func (f *FlusherDoris) Stop() error {
 	// Ensure Stop() is only executed once to avoid panic from closing channels twice
 	f.stopOnce.Do(func() {
+		defer f.cancelLoads()
+		if f.Concurrency <= 1 {
+			f.cancelLoads()
+		}
+
 		// Stop progress logging first
 		if f.progressTicker != nil {
 			close(f.stopChan)
@@ -412,6 +966,15 @@ func (f *FlusherDoris) Stop() error {
 
 		// Stop async workers if running
 		if f.Concurrency > 1 && f.queue != nil {
+			// Loads still running after the drain timeout are canceled, the remaining tasks then fail at once
+			drainTimer := time.AfterFunc(time.Duration(f.StopDrainTimeoutSeconds)*time.Second, func() {
+				logger.Warning(f.context.GetRuntimeContext(), "FLUSHER_FLUSH_ALARM",
+					"doris flusher queue not drained in time, canceling the loads in flight",
+					"stopDrainTimeoutSeconds", f.StopDrainTimeoutSeconds)
+				f.cancelLoads()
+			})
+			defer drainTimer.Stop()
+
 			// Wait for all pending tasks to be added
 			f.counter.Wait()
 
@@ -423,6 +986,11 @@ func (f *FlusherDoris) Stop() error {
 
 			logger.Info(f.context.GetRuntimeContext(), "Doris flusher async workers stopped")
 		}
+
+		// Report the totals of runs shorter than the progress interval too, a flusher never initialized has none
+		if f.context != nil {
+			logger.Info(f.context.GetRuntimeContext(), f.summary())
+		}
 	})
 
 	return nil
@@ -452,6 +1020,28 @@ func (f *FlusherDoris) updateStatistics(bytes, rows uint64) {
 	atomic.AddUint64(&f.stats.totalRows, rows)
 	atomic.AddUint64(&f.stats.lastBytes, bytes)
 	atomic.AddUint64(&f.stats.lastRows, rows)
+
+	loads := atomic.AddUint64(&f.stats.succeededLoads, 1)
+	if f.LogProgressEveryNLoads > 0 && loads%uint64(f.LogProgressEveryNLoads) == 0 {
+		// Format: 300 loads, total 11 MB 18978 ROWS in 30 seconds, average speed 0 MB/s 632 R/s
+		logger.Info(f.context.GetRuntimeContext(), fmt.Sprintf("%d loads, %s", loads, f.totals()))
+	}
+}
+
+// totals formats the lifetime totals shared by the every N loads log and the summary, it leaves the interval
+// figures to logProgress
+func (f *FlusherDoris) totals() string {
+	totalBytes := atomic.LoadUint64(&f.stats.totalBytes)
+	totalRows := atomic.LoadUint64(&f.stats.totalRows)
+	elapsed := time.Since(f.stats.startTime).Seconds()
+	if elapsed == 0 {
+		elapsed = 1
+	}
+	totalMB := float64(totalBytes) / 1024 / 1024
+
+	// Format: total 11 MB 18978 ROWS in 30 seconds, average speed 0 MB/s 632 R/s
+	return fmt.Sprintf("total %.0f MB %d ROWS in %.0f seconds, average speed %.0f MB/s %.0f R/s",
+		totalMB, totalRows, elapsed, totalMB/elapsed, float64(totalRows)/elapsed)
 }
 
 // logProgress logs the current progress statistics
@@ -503,6 +1093,39 @@ func (f *FlusherDoris) logProgress() {
 			lastSpeedMBps, lastSpeedRps))
 }
 
+// Stats returns a snapshot of the running statistics, it is safe to call concurrently with Flush
+func (f *FlusherDoris) Stats() FlusherStats {
+	f.stats.mu.Lock()
+	since := f.stats.lastReportTime
+	f.stats.mu.Unlock()
+	if since.IsZero() {
+		since = f.stats.startTime
+	}
+
+	now := time.Now()
+	stats := FlusherStats{
+		TotalBytes:    atomic.LoadUint64(&f.stats.totalBytes),
+		TotalRows:     atomic.LoadUint64(&f.stats.totalRows),
+		FailedLoads:   atomic.LoadUint64(&f.stats.failedLoads),
+		DroppedGroups: atomic.LoadUint64(&f.stats.droppedGroups),
+	}
+	if elapsed := now.Sub(f.stats.startTime).Seconds(); elapsed > 0 {
+		stats.AverageMBps = float64(stats.TotalBytes) / 1024 / 1024 / elapsed
+		stats.AverageRowsPerSecond = float64(stats.TotalRows) / elapsed
+	}
+	if elapsed := now.Sub(since).Seconds(); elapsed > 0 {
+		stats.CurrentMBps = float64(atomic.LoadUint64(&f.stats.lastBytes)) / 1024 / 1024 / elapsed
+		stats.CurrentRowsPerSecond = float64(atomic.LoadUint64(&f.stats.lastRows)) / elapsed
+	}
+	return stats
+}
+
+// summary describes the lifetime totals of the flusher
+func (f *FlusherDoris) summary() string {
+	// Format: doris flusher stopped, total 11 MB 18978 ROWS in 30 seconds, average speed 0 MB/s 632 R/s, 0 dropped log groups
+	return fmt.Sprintf("doris flusher stopped, %s, %d dropped log groups", f.totals(), atomic.LoadUint64(&f.stats.droppedGroups))
+}
+
 // Register the plugin to the Flushers array.
 func init() {
 	pipeline.Flushers["flusher_doris"] = func() pipeline.Flusher {
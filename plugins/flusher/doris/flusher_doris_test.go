@@ -16,12 +16,27 @@ package doris
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/apache/doris/sdk/go-doris-sdk/pkg/load"
+
+	"github.com/alibaba/ilogtail/pkg/logger"
 	"github.com/alibaba/ilogtail/pkg/protocol"
 	"github.com/alibaba/ilogtail/plugins/test"
 	"github.com/alibaba/ilogtail/plugins/test/mock"
@@ -247,7 +262,7 @@ func InvalidTestConnectAndWrite(t *testing.T) {
 	}
 
 	flusher := NewFlusherDoris()
-	flusher.Addresses = []string{"127.0.0.1:8030"}
+	flusher.Addresses = []string{"http://127.0.0.1:8030"}
 	flusher.Database = "test_db"
 	flusher.Table = "test_table"
 	flusher.GroupCommit = "off" // Test with default group commit mode
@@ -603,6 +618,861 @@ func TestFlusherDoris_ValidateAddresses(t *testing.T) {
 	}
 }
 
+func init() {
+	logger.InitTestLogger(logger.OptionOpenMemoryReceiver)
+}
+
+// memoryLogs returns the logs kept by the memory receiver that contain substr
+func memoryLogs(substr string) []string {
+	var logs []string
+	for line := 1; line <= logger.GetMemoryLogCount(); line++ {
+		if log, ok := logger.ReadMemoryLog(line); ok && strings.Contains(log, substr) {
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
+
+// mockDoris records the stream load requests received by a mock Doris FE
+type mockDoris struct {
+	mu      sync.Mutex
+	bodies  []string
+	headers []http.Header
+}
+
+// requests returns the bodies and headers of the received requests
+func (m *mockDoris) requests() ([]string, []http.Header) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.bodies...), append([]http.Header(nil), m.headers...)
+}
+
+// newMockDoris starts a mock Doris FE accepting every stream load
+func newMockDoris(t *testing.T) (*httptest.Server, *mockDoris) {
+	m := &mockDoris{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		m.mu.Lock()
+		m.bodies = append(m.bodies, string(body))
+		m.headers = append(m.headers, r.Header.Clone())
+		m.mu.Unlock()
+		_, _ = w.Write([]byte(`{"TxnId":1,"Label":"test","Status":"Success","NumberTotalRows":1,"NumberLoadedRows":1}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, m
+}
+
+// newTestFlusher creates a flusher targeting the mock Doris FE, configure runs before Init
+func newTestFlusher(t *testing.T, server *httptest.Server, configure func(f *FlusherDoris)) *FlusherDoris {
+	flusher := NewFlusherDoris()
+	flusher.Addresses = []string{server.URL}
+	flusher.Database = "test_db"
+	flusher.Table = "test_table"
+	flusher.LogProgressInterval = 0
+	flusher.Authentication.PlainText = &PlainTextConfig{Username: "root", Password: "password"}
+	if configure != nil {
+		configure(flusher)
+	}
+	require.NoError(t, flusher.Init(mock.NewEmptyContext("p", "l", "c")))
+	t.Cleanup(func() { _ = flusher.Stop() })
+	return flusher
+}
+
+// TestFlusherDoris_TimeColumn tests that the log time is written into the time column
+func TestFlusherDoris_TimeColumn(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeUnit string
+		expected uint64
+	}{
+		{name: "default unit", timeUnit: "", expected: 1700000000},
+		{name: "seconds", timeUnit: "seconds", expected: 1700000000},
+		{name: "millis", timeUnit: "millis", expected: 1700000000123},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, doris := newMockDoris(t)
+			flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+				f.TimeColumn = "event_time"
+				f.TimeUnit = tt.timeUnit
+			})
+
+			log := test.CreateLogByFields(map[string]string{"message": "hello"})
+			protocol.SetLogTimeWithNano(log, 1700000000, 123456789)
+			err := flusher.Flush("p", "l", "c", []*protocol.LogGroup{{Logs: []*protocol.Log{log}}})
+			require.NoError(t, err)
+
+			bodies, _ := doris.requests()
+			require.Len(t, bodies, 1)
+			var record map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(bodies[0])), &record))
+			assert.Equal(t, float64(tt.expected), record["event_time"])
+			assert.Contains(t, record, "contents")
+		})
+	}
+}
+
+// TestFlusherDoris_TimeColumnInColumnsHeader tests that the time column is added to an explicit columns list
+func TestFlusherDoris_TimeColumnInColumnsHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		columns  string
+		expected string
+	}{
+		{name: "no columns", columns: "", expected: ""},
+		{name: "added", columns: "contents,tags", expected: "contents,tags,event_time"},
+		{name: "already listed", columns: "contents, event_time", expected: "contents, event_time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, doris := newMockDoris(t)
+			flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+				f.TimeColumn = "event_time"
+				if tt.columns != "" {
+					f.LoadProperties = map[string]string{"columns": tt.columns}
+				}
+			})
+
+			err := flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1])
+			require.NoError(t, err)
+
+			_, headers := doris.requests()
+			require.Len(t, headers, 1)
+			assert.Equal(t, tt.expected, headers[0].Get("columns"))
+		})
+	}
+}
+
+// TestFlusherDoris_ConstantColumns tests that the constant columns are appended to the columns header
+func TestFlusherDoris_ConstantColumns(t *testing.T) {
+	server, doris := newMockDoris(t)
+	flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+		f.TimeColumn = "event_time"
+		f.LoadProperties = map[string]string{"columns": "contents,tags"}
+		f.ConstantColumns = map[string]string{"env": "prod", "dc": "hz"}
+	})
+
+	err := flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1])
+	require.NoError(t, err)
+
+	_, headers := doris.requests()
+	require.Len(t, headers, 1)
+	assert.Equal(t, "contents,tags,event_time,dc='hz',env='prod'", headers[0].Get("columns"))
+}
+
+// TestFlusherDoris_ConstantColumnsWithoutColumns tests that constant columns require an explicit columns list
+func TestFlusherDoris_ConstantColumnsWithoutColumns(t *testing.T) {
+	flusher := NewFlusherDoris()
+	flusher.Addresses = []string{"http://127.0.0.1:8030"}
+	flusher.Database = "test_db"
+	flusher.Table = "test_table"
+	flusher.Authentication.PlainText = &PlainTextConfig{Username: "root", Password: "password"}
+	flusher.ConstantColumns = map[string]string{"env": "prod"}
+	err := flusher.Init(mock.NewEmptyContext("p", "l", "c"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "constantColumns require an explicit columns list")
+}
+
+// TestFlusherDoris_InvalidTimeUnit tests the time unit validation
+func TestFlusherDoris_InvalidTimeUnit(t *testing.T) {
+	flusher := NewFlusherDoris()
+	flusher.Addresses = []string{"http://127.0.0.1:8030"}
+	flusher.Table = "test_table"
+	flusher.TimeUnit = "nanos"
+	flusher.context = mock.NewEmptyContext("p", "l", "c")
+	assert.Error(t, flusher.Validate())
+}
+
+// TestFlusherDoris_ConverterErrorPolicy tests the handling of LogGroups failing conversion
+func TestFlusherDoris_ConverterErrorPolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          string
+		wantErr         bool
+		wantLoad        bool
+		wantDeadLetters int
+	}{
+		{name: "default skips", policy: "", wantLoad: false},
+		{name: "skip", policy: "skip", wantLoad: false},
+		{name: "fail", policy: "fail", wantErr: true},
+		{name: "deadletter", policy: "deadletter", wantDeadLetters: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, doris := newMockDoris(t)
+			deadLetterPath := filepath.Join(t.TempDir(), "dead_letter.json")
+			flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+				f.ConverterErrorPolicy = tt.policy
+				f.DeadLetterPath = deadLetterPath
+			})
+			// Make every conversion fail
+			flusher.converter.Encoding = "unsupported"
+
+			err := flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1])
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			bodies, _ := doris.requests()
+			assert.Empty(t, bodies, "nothing is left to load")
+
+			content, err := os.ReadFile(deadLetterPath)
+			if tt.wantDeadLetters == 0 {
+				assert.True(t, os.IsNotExist(err))
+				return
+			}
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			require.Len(t, lines, tt.wantDeadLetters)
+			var record deadLetterRecord
+			require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+			assert.Contains(t, record.Error, "unsupported")
+			assert.Len(t, record.LogGroup.Logs, 10)
+		})
+	}
+}
+
+// TestFlusherDoris_ConverterErrorPolicyValidation tests the converter error policy validation
+func TestFlusherDoris_ConverterErrorPolicyValidation(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         string
+		deadLetterPath string
+		concurrency    int
+		wantErr        bool
+	}{
+		{name: "skip", policy: "skip"},
+		{name: "fail", policy: "fail"},
+		{name: "fail in async mode", policy: "fail", concurrency: 2, wantErr: true},
+		{name: "skip in async mode", policy: "skip", concurrency: 2},
+		{name: "deadletter with path", policy: "deadletter", deadLetterPath: "/tmp/dead_letter.json"},
+		{name: "deadletter without path", policy: "deadletter", wantErr: true},
+		{name: "unknown", policy: "retry", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flusher := NewFlusherDoris()
+			flusher.Addresses = []string{"http://127.0.0.1:8030"}
+			flusher.Table = "test_table"
+			flusher.ConverterErrorPolicy = tt.policy
+			flusher.DeadLetterPath = tt.deadLetterPath
+			if tt.concurrency > 0 {
+				flusher.Concurrency = tt.concurrency
+			}
+			flusher.context = mock.NewEmptyContext("p", "l", "c")
+			if tt.wantErr {
+				assert.Error(t, flusher.Validate())
+			} else {
+				assert.NoError(t, flusher.Validate())
+			}
+		})
+	}
+}
+
+// TestFlusherDoris_ConnectionPool tests that the connection pool limits reach the SDK configuration
+func TestFlusherDoris_ConnectionPool(t *testing.T) {
+	shared := NewFlusherDoris()
+	assert.Nil(t, shared.buildLoadConfig("root", "").ConnectionPool, "flushers without limits share the SDK pool")
+
+	first := NewFlusherDoris()
+	first.MaxConnsPerHost = 10
+	first.MaxIdleConnsPerHost = 5
+	second := NewFlusherDoris()
+	second.MaxConnsPerHost = 100
+	second.MaxIdleConns = 200
+
+	assert.Equal(t, &load.ConnectionPool{MaxConnsPerHost: 10, MaxIdleConnsPerHost: 5},
+		first.buildLoadConfig("root", "").ConnectionPool)
+	assert.Equal(t, &load.ConnectionPool{MaxConnsPerHost: 100, MaxIdleConns: 200},
+		second.buildLoadConfig("root", "").ConnectionPool)
+
+	// Both flushers load with their own pools
+	server, doris := newMockDoris(t)
+	for _, limits := range []*FlusherDoris{first, second} {
+		flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+			f.MaxConnsPerHost = limits.MaxConnsPerHost
+			f.MaxIdleConnsPerHost = limits.MaxIdleConnsPerHost
+			f.MaxIdleConns = limits.MaxIdleConns
+		})
+		require.NoError(t, flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1]))
+	}
+	bodies, _ := doris.requests()
+	assert.Len(t, bodies, 2)
+}
+
+func TestFlusherDoris_LabelTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    string
+		concurrency int
+		groupCommit string
+		wantPrefix  string
+	}{
+		{name: "sync", concurrency: 1, groupCommit: "off", wantPrefix: "lc-my_project-nginx_access-cfg_1_"},
+		{name: "async", concurrency: 2, groupCommit: "off", wantPrefix: "lc-my_project-nginx_access-cfg_1_"},
+		{name: "group commit", concurrency: 1, groupCommit: "async", wantPrefix: ""},
+		{name: "long template", template: "lc-{project}-" + strings.Repeat("x", 200), concurrency: 1, groupCommit: "off",
+			wantPrefix: "lc-my_project-xxx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, doris := newMockDoris(t)
+			flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+				f.LabelTemplate = "lc-{project}-{logstore}-{config}"
+				if tt.template != "" {
+					f.LabelTemplate = tt.template
+				}
+				f.Concurrency = tt.concurrency
+				f.GroupCommit = tt.groupCommit
+			})
+			require.NoError(t, flusher.Flush("my.project", "nginx/access", "cfg#1", makeTestLogGroupList().GetLogGroupList()[:1]))
+			require.NoError(t, flusher.Stop())
+
+			_, headers := doris.requests()
+			require.Len(t, headers, 1)
+			label := headers[0].Get("label")
+			if tt.wantPrefix == "" {
+				assert.Empty(t, label)
+				assert.Empty(t, flusher.LabelTemplate)
+				return
+			}
+			assert.True(t, strings.HasPrefix(label, tt.wantPrefix), "label %s", label)
+			assert.LessOrEqual(t, len(label), load.DefaultMaxLabelLength)
+		})
+	}
+}
+
+// TestFlusherDoris_FieldsRename tests that the top-level renames reach the converter
+func TestFlusherDoris_FieldsRename(t *testing.T) {
+	server, doris := newMockDoris(t)
+	flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+		f.Convert.ProtocolFieldsRename = map[string]string{"contents": "fields", "time": "timestamp"}
+		f.TagFieldsRename = map[string]string{"host": "hostname"}
+		f.ProtocolFieldsRename = map[string]string{"contents": "data"}
+	})
+	assert.Equal(t, map[string]string{"host": "hostname"}, flusher.Convert.TagFieldsRename)
+	assert.Equal(t, map[string]string{"contents": "data", "time": "timestamp"}, flusher.Convert.ProtocolFieldsRename)
+
+	log := test.CreateLogByFields(map[string]string{"message": "hello"})
+	require.NoError(t, flusher.Flush("p", "l", "c", []*protocol.LogGroup{{Logs: []*protocol.Log{log}}}))
+
+	bodies, _ := doris.requests()
+	require.Len(t, bodies, 1)
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(bodies[0])), &record))
+	assert.Contains(t, record, "data")
+	assert.Contains(t, record, "timestamp")
+	assert.NotContains(t, record, "contents")
+}
+
+// TestFlusherDoris_ProtocolFieldsRenameValidation tests that only protocol fields can be renamed
+func TestFlusherDoris_ProtocolFieldsRenameValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		renames map[string]string
+		convert map[string]string
+		wantErr bool
+	}{
+		{name: "protocol fields", renames: map[string]string{"contents": "data", "tags": "labels", "time": "ts"}},
+		{name: "unknown field", renames: map[string]string{"message": "msg"}, wantErr: true},
+		{name: "unknown field in convert", convert: map[string]string{"content": "data"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flusher := NewFlusherDoris()
+			flusher.Addresses = []string{"http://127.0.0.1:8030"}
+			flusher.Table = "test_table"
+			flusher.ProtocolFieldsRename = tt.renames
+			flusher.Convert.ProtocolFieldsRename = tt.convert
+			flusher.context = mock.NewEmptyContext("p", "l", "c")
+			if tt.wantErr {
+				assert.Error(t, flusher.Validate())
+			} else {
+				assert.NoError(t, flusher.Validate())
+			}
+		})
+	}
+}
+
+// TestFlusherDoris_OverflowPolicy tests the overflow policies while Doris does not answer
+func TestFlusherDoris_OverflowPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           string
+		queueCapacity    int
+		maxBufferedBytes bool
+		expected         []string
+	}{
+		{name: "block", policy: "block", queueCapacity: 1, expected: []string{"a", "b", "c", "d"}},
+		{name: "default blocks", policy: "", queueCapacity: 1, expected: []string{"a", "b", "c", "d"}},
+		{name: "drop oldest", policy: "drop_oldest", queueCapacity: 1, expected: []string{"a", "b", "d"}},
+		{name: "drop newest", policy: "drop_newest", queueCapacity: 1, expected: []string{"a", "b", "c"}},
+		{name: "drop newest over max buffered bytes", policy: "drop_newest", queueCapacity: 10, maxBufferedBytes: true,
+			expected: []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			var mu sync.Mutex
+			var received []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				received = append(received, string(body))
+				mu.Unlock()
+				<-release
+				_, _ = w.Write([]byte(`{"TxnId":1,"Label":"test","Status":"Success","NumberTotalRows":1,"NumberLoadedRows":1}`))
+			}))
+			t.Cleanup(server.Close)
+			receivedCount := func() int {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(received)
+			}
+
+			logGroup := func(message string) []*protocol.LogGroup {
+				log := test.CreateLogByFields(map[string]string{"message": "msg-" + message})
+				return []*protocol.LogGroup{{Logs: []*protocol.Log{log}}}
+			}
+			flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+				f.Concurrency = 2
+				f.QueueCapacity = tt.queueCapacity
+				f.OverflowPolicy = tt.policy
+				if tt.maxBufferedBytes {
+					f.MaxBufferedBytes = int64(logGroup("c")[0].Size())
+				}
+			})
+
+			// Both workers are busy with a and b, c waits in the queue and d overflows
+			for i, message := range []string{"a", "b"} {
+				require.NoError(t, flusher.Flush("p", "l", "c", logGroup(message)))
+				require.Eventually(t, func() bool { return receivedCount() == i+1 }, 5*time.Second, 10*time.Millisecond)
+			}
+			require.NoError(t, flusher.Flush("p", "l", "c", logGroup("c")))
+
+			done := make(chan struct{})
+			go func() {
+				assert.NoError(t, flusher.Flush("p", "l", "c", logGroup("d")))
+				close(done)
+			}()
+			if tt.policy == "" || tt.policy == "block" {
+				select {
+				case <-done:
+					t.Fatal("flush should block while the queue is full")
+				case <-time.After(100 * time.Millisecond):
+				}
+			} else {
+				<-done
+				assert.Equal(t, uint64(1), atomic.LoadUint64(&flusher.stats.droppedGroups))
+			}
+
+			close(release)
+			<-done
+			require.NoError(t, flusher.Stop())
+
+			mu.Lock()
+			defer mu.Unlock()
+			var messages []string
+			for _, body := range received {
+				for _, message := range []string{"a", "b", "c", "d"} {
+					if strings.Contains(body, "msg-"+message) {
+						messages = append(messages, message)
+					}
+				}
+			}
+			sort.Strings(messages)
+			assert.Equal(t, tt.expected, messages)
+		})
+	}
+}
+
+// TestFlusherDoris_StopCancelsLoad tests that Stop cancels a load blocked on a slow Doris
+func TestFlusherDoris_StopCancelsLoad(t *testing.T) {
+	received := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		received <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(30 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+	flusher := newTestFlusher(t, server, nil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1])
+	}()
+	<-received
+
+	start := time.Now()
+	require.NoError(t, flusher.Stop())
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Stop did not cancel the load in flight")
+	}
+}
+
+// TestFlusherDoris_StopCancelsAsyncLoad tests that Stop cancels a stuck load of an async worker after the drain timeout
+func TestFlusherDoris_StopCancelsAsyncLoad(t *testing.T) {
+	received := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		received <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(30 * time.Second):
+		}
+	}))
+	t.Cleanup(server.Close)
+	flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+		f.Concurrency = 2
+		f.StopDrainTimeoutSeconds = 1
+	})
+
+	require.NoError(t, flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1]))
+	<-received
+
+	stopped := make(chan struct{})
+	start := time.Now()
+	go func() {
+		assert.NoError(t, flusher.Stop())
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Stop did not cancel the stuck load after the drain timeout")
+	}
+}
+
+// tsvSerializer writes the message and level contents of each log as a TSV line
+type tsvSerializer struct {
+	failOn string
+}
+
+func (s *tsvSerializer) Serialize(logGroup *protocol.LogGroup) ([]byte, error) {
+	var buffer bytes.Buffer
+	for _, log := range logGroup.Logs {
+		values := map[string]string{}
+		for _, content := range log.Contents {
+			values[content.Key] = content.Value
+		}
+		if s.failOn != "" && values["message"] == s.failOn {
+			return nil, fmt.Errorf("cannot serialize %q", s.failOn)
+		}
+		buffer.WriteString(values["message"] + "\t" + values["level"] + "\n")
+	}
+	return buffer.Bytes(), nil
+}
+
+func (s *tsvSerializer) Format() load.Format {
+	return &load.CSVFormat{ColumnSeparator: "\t", LineDelimiter: "\n"}
+}
+
+// TestFlusherDoris_Serializer tests that a custom serializer replaces the converter and sets the load format
+func TestFlusherDoris_Serializer(t *testing.T) {
+	server, doris := newMockDoris(t)
+	flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+		f.Serializer = &tsvSerializer{failOn: "broken"}
+		f.TimeColumn = "event_time"
+	})
+	assert.Empty(t, flusher.TimeColumn)
+
+	logGroups := []*protocol.LogGroup{
+		{Logs: []*protocol.Log{
+			test.CreateLogByFields(map[string]string{"message": "hello", "level": "info"}),
+			test.CreateLogByFields(map[string]string{"message": "world", "level": "warn"}),
+		}},
+		{Logs: []*protocol.Log{test.CreateLogByFields(map[string]string{"message": "broken", "level": "error"})}},
+	}
+	require.NoError(t, flusher.Flush("p", "l", "c", logGroups))
+
+	bodies, headers := doris.requests()
+	require.Len(t, bodies, 1)
+	assert.Equal(t, "hello\tinfo\nworld\twarn\n", bodies[0])
+	assert.Equal(t, "csv", headers[0].Get("format"))
+	assert.Equal(t, `\t`, headers[0].Get("column_separator"))
+}
+
+// TestFlusherDoris_RecordTransform tests fields added and removed by the transform and logs it drops
+func TestFlusherDoris_RecordTransform(t *testing.T) {
+	server, doris := newMockDoris(t)
+	flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+		f.RecordTransform = func(fields map[string]string) map[string]string {
+			if fields["level"] == "debug" {
+				return nil
+			}
+			delete(fields, "password")
+			fields["source"] = "collector-A"
+			return fields
+		}
+	})
+
+	original := test.CreateLogByFields(map[string]string{"message": "hello", "password": "secret"})
+	logGroups := []*protocol.LogGroup{
+		{Logs: []*protocol.Log{original, test.CreateLogByFields(map[string]string{"message": "noise", "level": "debug"})}},
+		{Logs: []*protocol.Log{test.CreateLogByFields(map[string]string{"message": "dropped", "level": "debug"})}},
+	}
+	require.NoError(t, flusher.Flush("p", "l", "c", logGroups))
+
+	bodies, _ := doris.requests()
+	require.Len(t, bodies, 1)
+	lines := strings.Split(strings.TrimSpace(bodies[0]), "\n")
+	require.Len(t, lines, 1)
+	var record struct {
+		Contents map[string]string `json:"contents"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, map[string]string{"message": "hello", "source": "collector-A"}, record.Contents)
+
+	// The flushed logs are left untouched
+	assert.Len(t, original.Contents, 2)
+	assert.Len(t, logGroups[0].Logs, 2)
+}
+
+// TestFlusherDoris_Stats tests that the snapshot reflects successful and failed loads
+func TestFlusherDoris_Stats(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		if atomic.AddInt32(&requests, 1) == 3 {
+			_, _ = w.Write([]byte(`{"Status":"Fail","Message":"[DATA_QUALITY_ERROR]too many filtered rows"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"TxnId":1,"Status":"Success","NumberTotalRows":2,"NumberLoadedRows":2,"LoadBytes":100}`))
+	}))
+	t.Cleanup(server.Close)
+	flusher := newTestFlusher(t, server, nil)
+	assert.Equal(t, FlusherStats{}, flusher.Stats())
+
+	// Read the stats while flushing, run with -race to check the snapshot is safe
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = flusher.Stats()
+		}
+	}()
+	require.NoError(t, flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1]))
+	require.NoError(t, flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1]))
+	require.Error(t, flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1]))
+	<-done
+
+	stats := flusher.Stats()
+	assert.Equal(t, uint64(200), stats.TotalBytes)
+	assert.Equal(t, uint64(4), stats.TotalRows)
+	assert.Equal(t, uint64(1), stats.FailedLoads)
+	assert.Zero(t, stats.DroppedGroups)
+	assert.Greater(t, stats.AverageRowsPerSecond, 0.0)
+	assert.Greater(t, stats.CurrentRowsPerSecond, 0.0)
+	assert.Greater(t, stats.CurrentMBps, 0.0)
+}
+
+// TestFlusherDoris_LoadGranularity tests the number of loads of a Flush with several LogGroups
+func TestFlusherDoris_LoadGranularity(t *testing.T) {
+	tests := []struct {
+		granularity string
+		loads       int
+	}{
+		{granularity: "", loads: 1},
+		{granularity: "per_flush", loads: 1},
+		{granularity: "per_group", loads: 3},
+	}
+	for _, tt := range tests {
+		t.Run("granularity "+tt.granularity, func(t *testing.T) {
+			server, doris := newMockDoris(t)
+			flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+				f.LoadGranularity = tt.granularity
+			})
+			logGroups := makeTestLogGroupList().GetLogGroupList()[:3]
+			require.NoError(t, flusher.Flush("p", "l", "c", logGroups))
+
+			bodies, _ := doris.requests()
+			assert.Len(t, bodies, tt.loads)
+			assert.Equal(t, 30, strings.Count(strings.Join(bodies, ""), "\n"))
+		})
+	}
+
+	flusher := NewFlusherDoris()
+	flusher.Addresses = []string{"http://127.0.0.1:8030"}
+	flusher.Table = "test_table"
+	flusher.LoadGranularity = "per_row"
+	flusher.context = mock.NewEmptyContext("p", "l", "c")
+	assert.EqualError(t, flusher.Validate(), "doris load granularity must be per_flush or per_group, got per_row")
+}
+
+// TestFlusherDoris_DeleteSign tests the hidden_columns header and the delete sign of each record
+func TestFlusherDoris_DeleteSign(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected []float64
+	}{
+		{name: "default values", expected: []float64{0, 1, 0, 0}},
+		{name: "custom values", values: []string{"delete", "D"}, expected: []float64{0, 1, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, doris := newMockDoris(t)
+			flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+				f.DeleteSignField = "op"
+				f.DeleteSignValues = tt.values
+				f.TimeColumn = "event_time"
+			})
+
+			logs := []*protocol.Log{
+				test.CreateLogByFields(map[string]string{"id": "1", "op": "insert"}),
+				test.CreateLogByFields(map[string]string{"id": "2", "op": "delete"}),
+				test.CreateLogByFields(map[string]string{"id": "3", "op": "D"}),
+				test.CreateLogByFields(map[string]string{"id": "4"}),
+			}
+			require.NoError(t, flusher.Flush("p", "l", "c", []*protocol.LogGroup{{Logs: logs}}))
+
+			bodies, headers := doris.requests()
+			require.Len(t, bodies, 1)
+			assert.Equal(t, load.DeleteSignColumn, headers[0].Get("hidden_columns"))
+			lines := strings.Split(strings.TrimSpace(bodies[0]), "\n")
+			require.Len(t, lines, len(tt.expected))
+			for i, line := range lines {
+				var record map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(line), &record))
+				assert.Equal(t, tt.expected[i], record[load.DeleteSignColumn], "record %d", i)
+				assert.Contains(t, record, "event_time")
+			}
+		})
+	}
+}
+
+// TestFlusherDoris_LogProgressEveryNLoads tests that the progress is logged after every N successful loads
+func TestFlusherDoris_LogProgressEveryNLoads(t *testing.T) {
+	server, _ := newMockDoris(t)
+	flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+		f.LogProgressEveryNLoads = 3
+	})
+	logger.ClearMemoryLog()
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1]))
+		if i < 3 {
+			assert.Empty(t, memoryLogs("loads, total"), "after %d loads", i)
+		}
+	}
+	logs := memoryLogs("loads, total")
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], "3 loads, total 0 MB 3 ROWS")
+	// Only the lifetime totals are logged, the interval figures are left to the interval ticker
+	assert.Equal(t, uint64(3), atomic.LoadUint64(&flusher.stats.lastRows))
+
+	flusher = NewFlusherDoris()
+	flusher.Addresses = []string{"http://127.0.0.1:8030"}
+	flusher.Table = "test_table"
+	flusher.LogProgressEveryNLoads = -1
+	flusher.context = mock.NewEmptyContext("p", "l", "c")
+	assert.EqualError(t, flusher.Validate(), "doris log progress every n loads cannot be negative, got -1")
+}
+
+// TestFlusherDoris_LineTermination tests that each record ends with a single newline, whether the converter ends
+// its lines or not
+func TestFlusherDoris_LineTermination(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol string
+		encoding string
+		fields   map[string]string
+	}{
+		{name: "unterminated json", protocol: "custom_single", encoding: "json", fields: map[string]string{"message": "hello"}},
+		{name: "terminated influxdb", protocol: "influxdb", encoding: "custom", fields: map[string]string{
+			"__name__": "cpu", "__labels__": "host#$#a", "__value__": "1", "__time_nano__": "1700000000000000000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, doris := newMockDoris(t)
+			flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+				f.Convert.Protocol = tt.protocol
+				f.Convert.Encoding = tt.encoding
+			})
+			logs := []*protocol.Log{test.CreateLogByFields(tt.fields)}
+			require.NoError(t, flusher.Flush("p", "l", "c", []*protocol.LogGroup{{Logs: logs}}))
+
+			bodies, _ := doris.requests()
+			require.Len(t, bodies, 1)
+			assert.NotContains(t, bodies[0], "\n\n")
+			assert.True(t, strings.HasSuffix(bodies[0], "\n"), "body %q", bodies[0])
+			assert.Equal(t, 1, strings.Count(bodies[0], "\n"))
+		})
+	}
+}
+
+// TestFlusherDoris_DropOldestConcurrentWorker tests that drop_oldest producers do not wedge the flusher when a worker
+// has taken a task off the queue but not yet released its bytes
+func TestFlusherDoris_DropOldestConcurrentWorker(t *testing.T) {
+	server, _ := newMockDoris(t)
+	logGroups := makeTestLogGroupList().GetLogGroupList()[:1]
+	flusher := newTestFlusher(t, server, func(f *FlusherDoris) {
+		f.Concurrency = 2
+		f.QueueCapacity = 1
+		f.OverflowPolicy = "drop_oldest"
+		f.MaxBufferedBytes = int64(logGroups[0].Size())
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					assert.NoError(t, flusher.Flush("p", "l", "c", logGroups))
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("drop_oldest producers are stuck")
+	}
+	require.NoError(t, flusher.Stop())
+}
+
+// TestFlusherDoris_StopSummary tests the lifetime totals logged by Stop, even with progress logging disabled
+func TestFlusherDoris_StopSummary(t *testing.T) {
+	server, _ := newMockDoris(t)
+	flusher := newTestFlusher(t, server, nil)
+	require.NoError(t, flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1]))
+	require.NoError(t, flusher.Flush("p", "l", "c", makeTestLogGroupList().GetLogGroupList()[:1]))
+	atomic.AddUint64(&flusher.stats.droppedGroups, 3)
+	logger.ClearMemoryLog()
+	require.NoError(t, flusher.Stop())
+
+	// The mock Doris reports one loaded row per load
+	logs := memoryLogs("doris flusher stopped")
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], "doris flusher stopped, total 0 MB 2 ROWS")
+	assert.Contains(t, logs[0], "3 dropped log groups")
+}
+
 // BenchmarkFlusherDoris_MakeTestLogGroupList benchmarks log group creation
 func BenchmarkFlusherDoris_MakeTestLogGroupList(b *testing.B) {
 	for i := 0; i < b.N; i++ {
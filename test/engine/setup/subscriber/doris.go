@@ -16,14 +16,17 @@ package subscriber
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
-	// Import mysql driver for database/sql
-	_ "github.com/go-sql-driver/mysql"
+	// The mysql driver registers itself for database/sql
+	"github.com/go-sql-driver/mysql"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/alibaba/ilogtail/pkg/doc"
@@ -32,7 +35,27 @@ import (
 )
 
 const dorisName = "doris"
-const dorisQuerySQL = "select time, content, value from `%s`.`%s` where time > %v order by time limit 100"
+
+// dorisQuerySQL is the default query template, the arguments are:
+// 1: selected columns, 2: database, 3: table, 4: time column, 5: last timestamp, 6: limit
+const dorisQuerySQL = "select %[1]s from `%[2]s`.`%[3]s` where %[4]s > %[5]v order by %[4]s limit %[6]d"
+
+// dorisDefaultQueryLimit is the default number of rows per query, the following ones are queried by pages
+const dorisDefaultQueryLimit = 100
+
+var dorisDefaultQueryColumns = []string{"time", "content", "value"}
+
+// dorisTLSCustom verifies the query port with TLSCAFile, through the TLS config registered as dorisTLSConfigName
+const (
+	dorisTLSCustom     = "custom"
+	dorisTLSConfigName = "doris-subscriber"
+)
+
+// Units of the timestamp column
+const (
+	dorisTimeUnitSeconds = "s"
+	dorisTimeUnitMillis  = "ms"
+)
 
 type DorisSubscriber struct {
 	Address     string `mapstructure:"address" comment:"the doris FE address (format: http://host:port)"`
@@ -41,6 +64,23 @@ type DorisSubscriber struct {
 	Database    string `mapstructure:"database" comment:"the doris database name to query from"`
 	Table       string `mapstructure:"table" comment:"the doris table name to query from"`
 	CreateTable bool   `mapstructure:"create_table" comment:"if create the table, default is true"`
+	// The first column is treated as the integer timestamp column, the others are returned as log contents
+	QueryColumns  []string `mapstructure:"query_columns" comment:"the columns to query, the first one must be the timestamp column, default is [time, content, value]"`
+	QueryTemplate string   `mapstructure:"query_template" comment:"the fmt template of the query ordered by time, args: 1 columns, 2 database, 3 table, 4 time column, 5 last timestamp, 6 limit"`
+	QueryLimit    int      `mapstructure:"query_limit" comment:"the rows per query, more rows are queried by pages, default is 100"`
+	TimeUnit      string   `mapstructure:"time_unit" comment:"the unit of the timestamp column, s or ms, default is s"`
+	TLS           string   `mapstructure:"tls" comment:"the tls mode of the query connection: true, skip-verify, preferred or custom, default is no tls"`
+	TLSCAFile     string   `mapstructure:"tls_ca_file" comment:"the CA certificate file verifying the query port, required by the custom tls mode"`
+
+	// DefaultPassword is only used when Password is empty, e.g. the password of a test cluster
+	DefaultPassword string `mapstructure:"default_password" comment:"the doris password used when password is empty, default is empty"`
+
+	// The connection is retried while Doris may still be starting, the interval doubles after each attempt
+	ConnectAttempts        int `mapstructure:"connect_attempts" comment:"the attempts to connect to doris, default is 5"`
+	ConnectRetryIntervalMs int `mapstructure:"connect_retry_interval_ms" comment:"the interval before the first connection retry in milliseconds, default is 1000"`
+
+	// open opens the query connection, sql.Open of the mysql driver if nil
+	open func(dsn string) (*sql.DB, error)
 
 	client        *sql.DB
 	lastTimestamp int64
@@ -58,6 +98,9 @@ func (d *DorisSubscriber) GetData(sqlStr string, startTime int32) ([]*protocol.L
 	// Set timestamp on first call
 	if d.lastTimestamp == 0 {
 		d.lastTimestamp = int64(startTime)
+		if d.TimeUnit == dorisTimeUnitMillis {
+			d.lastTimestamp *= 1000
+		}
 	}
 
 	// Connect to Doris only once
@@ -74,23 +117,19 @@ func (d *DorisSubscriber) GetData(sqlStr string, startTime int32) ([]*protocol.L
 		dorisHost := parts[0]
 		queryPort := "9030"
 
-		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
-			d.Username, d.Password, dorisHost, queryPort, d.Database)
-
-		db, err := sql.Open("mysql", dsn)
+		if d.password() == "" {
+			logger.Warningf(context.Background(), "DORIS_SUBSCRIBER_ALARM",
+				"no password set for doris user %s, connecting without password", d.Username)
+		}
+		dsn, err := d.buildDSN(dorisHost, queryPort)
 		if err != nil {
 			logger.Warningf(context.Background(), "DORIS_SUBSCRIBER_ALARM",
-				"failed to open doris connection, host %s, err: %s", host, err)
+				"failed to configure doris connection, host %s, err: %s", host, err)
 			return nil, err
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		if err = db.PingContext(ctx); err != nil {
-			logger.Warningf(context.Background(), "DORIS_SUBSCRIBER_ALARM",
-				"failed to ping doris, host %s, err: %s", host, err)
-			_ = db.Close()
+		db, err := d.connect(host, dsn)
+		if err != nil {
 			return nil, err
 		}
 
@@ -106,6 +145,46 @@ func (d *DorisSubscriber) GetData(sqlStr string, startTime int32) ([]*protocol.L
 	return []*protocol.LogGroup{logGroup}, nil
 }
 
+// connect opens and pings the query connection, retrying with a doubling interval while Doris may still be starting.
+func (d *DorisSubscriber) connect(host, dsn string) (*sql.DB, error) {
+	open := d.open
+	if open == nil {
+		open = func(dsn string) (*sql.DB, error) { return sql.Open("mysql", dsn) }
+	}
+	attempts := d.ConnectAttempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+	interval := time.Duration(d.ConnectRetryIntervalMs) * time.Millisecond
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var db *sql.DB
+		if db, err = open(dsn); err != nil {
+			logger.Warningf(context.Background(), "DORIS_SUBSCRIBER_ALARM",
+				"failed to open doris connection, host %s, attempt %d/%d, err: %s", host, attempt, attempts, err)
+		} else if err = pingDoris(db); err != nil {
+			logger.Warningf(context.Background(), "DORIS_SUBSCRIBER_ALARM",
+				"failed to ping doris, host %s, attempt %d/%d, err: %s", host, attempt, attempts, err)
+			_ = db.Close()
+		} else {
+			return db, nil
+		}
+		if attempt >= attempts {
+			return nil, err
+		}
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+// pingDoris checks the connection within the ping timeout.
+func pingDoris(db *sql.DB) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
 func (d *DorisSubscriber) FlusherConfig() string {
 	return ""
 }
@@ -122,7 +201,45 @@ func (d *DorisSubscriber) queryRecords() (logGroup *protocol.LogGroup, err error
 		Logs: []*protocol.Log{},
 	}
 
-	query := fmt.Sprintf(dorisQuerySQL, d.Database, d.Table, d.lastTimestamp)
+	// Query pages until one is not full, so that the rows beyond the limit are not left for the next call
+	limit := d.queryLimit()
+	for {
+		var logs []*protocol.Log
+		var timestamps []int64
+		if logs, timestamps, err = d.queryPage(limit); err != nil {
+			return
+		}
+		if len(logs) < limit {
+			logGroup.Logs = append(logGroup.Logs, logs...)
+			d.advanceTimestamp(timestamps)
+			break
+		}
+
+		// The rows of the last timestamp of a full page may go on in the next page, they are queried again with it
+		kept := len(logs)
+		for kept > 0 && timestamps[kept-1] == timestamps[len(logs)-1] {
+			kept--
+		}
+		if kept == 0 {
+			logger.Warningf(context.Background(), "DORIS_SUBSCRIBER_ALARM",
+				"at least %d rows share timestamp %d, increase query_limit to get all of them", limit, timestamps[0])
+			kept = len(logs)
+		}
+		previous := d.lastTimestamp
+		logGroup.Logs = append(logGroup.Logs, logs[:kept]...)
+		d.advanceTimestamp(timestamps[:kept])
+		if d.lastTimestamp <= previous {
+			break
+		}
+	}
+
+	logger.Infof(context.Background(), "doris subscriber got %d logs", len(logGroup.Logs))
+	return
+}
+
+// queryPage queries the rows after the last timestamp, returning the logs and their timestamps in the query order.
+func (d *DorisSubscriber) queryPage(limit int) (logs []*protocol.Log, timestamps []int64, err error) {
+	query := d.buildQuery(limit)
 	logger.Debugf(context.Background(), "doris subscriber query: %s", query)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -136,60 +253,127 @@ func (d *DorisSubscriber) queryRecords() (logGroup *protocol.LogGroup, err error
 	}
 	defer rows.Close()
 
+	columns := d.queryColumns()
 	for rows.Next() {
-		var (
-			timestamp int64
-			content   sql.NullString
-			value     sql.NullString
-		)
-		if err = rows.Scan(&timestamp, &content, &value); err != nil {
+		var timestamp int64
+		values := make([]sql.NullString, len(columns)-1)
+		dest := make([]interface{}, 0, len(columns))
+		dest = append(dest, &timestamp)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err = rows.Scan(dest...); err != nil {
 			logger.Warningf(context.Background(), "DORIS_SUBSCRIBER_ALARM",
 				"failed to scan row, err: %s", err)
 			return
 		}
 
-		log := &protocol.Log{
-			Time: uint32(timestamp),
+		log := &protocol.Log{}
+		d.setLogTime(log, timestamp)
+
+		// Add the non-null columns as contents
+		for i, value := range values {
+			if value.Valid {
+				log.Contents = append(log.Contents, &protocol.Log_Content{
+					Key:   columns[i+1],
+					Value: value.String,
+				})
+			}
 		}
 
-		// Add content field
-		if content.Valid {
-			log.Contents = append(log.Contents, &protocol.Log_Content{
-				Key:   "content",
-				Value: content.String,
-			})
-		}
+		logs = append(logs, log)
+		timestamps = append(timestamps, timestamp)
+	}
 
-		// Add value field
-		if value.Valid {
-			log.Contents = append(log.Contents, &protocol.Log_Content{
-				Key:   "value",
-				Value: value.String,
-			})
-		}
+	if err = rows.Err(); err != nil {
+		logger.Warningf(context.Background(), "DORIS_SUBSCRIBER_ALARM",
+			"rows iteration error: %s", err)
+	}
+	return
+}
 
-		// Update last timestamp
+// advanceTimestamp moves the last timestamp to the greatest of the returned ones.
+func (d *DorisSubscriber) advanceTimestamp(timestamps []int64) {
+	for _, timestamp := range timestamps {
 		if timestamp > d.lastTimestamp {
 			d.lastTimestamp = timestamp
 		}
+	}
+}
 
-		logGroup.Logs = append(logGroup.Logs, log)
+// password returns the configured password, or DefaultPassword if it is empty.
+func (d *DorisSubscriber) password() string {
+	if d.Password == "" {
+		return d.DefaultPassword
 	}
+	return d.Password
+}
 
-	if err = rows.Err(); err != nil {
-		logger.Warningf(context.Background(), "DORIS_SUBSCRIBER_ALARM",
-			"rows iteration error: %s", err)
+// buildDSN returns the MySQL DSN of the query port, registering the custom TLS config when needed.
+func (d *DorisSubscriber) buildDSN(host, port string) (string, error) {
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", d.Username, d.password(), host, port, d.Database)
+	switch d.TLS {
+	case "":
+		return dsn, nil
+	case dorisTLSCustom:
+		pem, err := os.ReadFile(d.TLSCAFile)
+		if err != nil {
+			return "", fmt.Errorf("read tls ca file: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return "", fmt.Errorf("no certificate found in tls ca file %s", d.TLSCAFile)
+		}
+		if err = mysql.RegisterTLSConfig(dorisTLSConfigName, &tls.Config{RootCAs: rootCAs, ServerName: host}); err != nil {
+			return "", err
+		}
+		return dsn + "?tls=" + dorisTLSConfigName, nil
+	default:
+		return dsn + "?tls=" + d.TLS, nil
+	}
+}
+
+// setLogTime sets the time of the log from a timestamp in the configured unit.
+func (d *DorisSubscriber) setLogTime(log *protocol.Log, timestamp int64) {
+	if d.TimeUnit == dorisTimeUnitMillis {
+		protocol.SetLogTimeWithNano(log, uint32(timestamp/1000), uint32(timestamp%1000*int64(time.Millisecond)))
 		return
 	}
+	log.Time = uint32(timestamp)
+}
 
-	logger.Infof(context.Background(), "doris subscriber got %d logs", len(logGroup.Logs))
-	return
+// queryColumns returns the configured query columns, or the default ones if not set.
+func (d *DorisSubscriber) queryColumns() []string {
+	if len(d.QueryColumns) == 0 {
+		return dorisDefaultQueryColumns
+	}
+	return d.QueryColumns
+}
+
+// buildQuery renders the query template with the current columns and timestamp.
+func (d *DorisSubscriber) buildQuery(limit int) string {
+	template := d.QueryTemplate
+	if template == "" {
+		template = dorisQuerySQL
+	}
+	columns := d.queryColumns()
+	return fmt.Sprintf(template, strings.Join(columns, ", "), d.Database, d.Table, columns[0], d.lastTimestamp, limit)
+}
+
+// queryLimit returns the configured rows per query, or the default one if not set.
+func (d *DorisSubscriber) queryLimit() int {
+	if d.QueryLimit <= 0 {
+		return dorisDefaultQueryLimit
+	}
+	return d.QueryLimit
 }
 
 func init() {
 	RegisterCreator(dorisName, func(spec map[string]interface{}) (Subscriber, error) {
 		i := &DorisSubscriber{
-			CreateTable: true,
+			CreateTable:            true,
+			ConnectAttempts:        5,
+			ConnectRetryIntervalMs: 1000,
 		}
 		if err := mapstructure.Decode(spec, i); err != nil {
 			return nil, err
@@ -204,6 +388,32 @@ func init() {
 		if i.Table == "" {
 			return nil, errors.New("table must no be empty")
 		}
+		if i.TimeUnit != "" && i.TimeUnit != dorisTimeUnitSeconds && i.TimeUnit != dorisTimeUnitMillis {
+			return nil, errors.New("time unit must be s or ms")
+		}
+		if i.QueryLimit < 0 {
+			return nil, errors.New("query limit must not be negative")
+		}
+		if i.ConnectAttempts <= 0 {
+			return nil, errors.New("connect attempts must be positive")
+		}
+		if i.ConnectRetryIntervalMs < 0 {
+			return nil, errors.New("connect retry interval must not be negative")
+		}
+		switch i.TLS {
+		case "", "true", "skip-verify", "preferred":
+		case dorisTLSCustom:
+			if i.TLSCAFile == "" {
+				return nil, errors.New("tls ca file must not be empty with custom tls")
+			}
+		default:
+			return nil, errors.New("tls must be true, skip-verify, preferred or custom")
+		}
+		for _, column := range i.QueryColumns {
+			if strings.TrimSpace(column) == "" {
+				return nil, errors.New("query columns must not contain empty column")
+			}
+		}
 		return i, nil
 	})
 	doc.Register("subscriber", dorisName, new(DorisSubscriber))
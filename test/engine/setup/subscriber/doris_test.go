@@ -0,0 +1,300 @@
+// Copyright 2025 LoongCollector Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package subscriber
+
+import (
+	"database/sql"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDorisSubscriber_DefaultQuery tests the query keeps the default columns when not configured
+func TestDorisSubscriber_DefaultQuery(t *testing.T) {
+	d := &DorisSubscriber{Database: "db", Table: "tbl", lastTimestamp: 100}
+	assert.Equal(t, "select time, content, value from `db`.`tbl` where time > 100 order by time limit 100", d.buildQuery(d.queryLimit()))
+}
+
+// TestDorisSubscriber_CustomColumns tests querying and scanning a custom column set
+func TestDorisSubscriber_CustomColumns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	d := &DorisSubscriber{
+		Database:     "db",
+		Table:        "tbl",
+		QueryColumns: []string{"ts", "host", "level", "msg"},
+		client:       db,
+	}
+
+	query := "select ts, host, level, msg from `db`.`tbl` where ts > 0 order by ts limit 100"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(
+		sqlmock.NewRows([]string{"ts", "host", "level", "msg"}).
+			AddRow(int64(10), "host-a", "INFO", "hello").
+			AddRow(int64(20), "host-b", nil, "world"))
+
+	logGroup, err := d.queryRecords()
+	require.NoError(t, err)
+	require.Len(t, logGroup.Logs, 2)
+
+	assert.Equal(t, uint32(10), logGroup.Logs[0].Time)
+	require.Len(t, logGroup.Logs[0].Contents, 3)
+	assert.Equal(t, "host", logGroup.Logs[0].Contents[0].Key)
+	assert.Equal(t, "host-a", logGroup.Logs[0].Contents[0].Value)
+	assert.Equal(t, "level", logGroup.Logs[0].Contents[1].Key)
+	assert.Equal(t, "msg", logGroup.Logs[0].Contents[2].Key)
+	assert.Equal(t, "hello", logGroup.Logs[0].Contents[2].Value)
+
+	// NULL columns are skipped
+	require.Len(t, logGroup.Logs[1].Contents, 2)
+	assert.Equal(t, "msg", logGroup.Logs[1].Contents[1].Key)
+	assert.Equal(t, "world", logGroup.Logs[1].Contents[1].Value)
+
+	assert.Equal(t, int64(20), d.lastTimestamp)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestDorisSubscriber_CustomTemplate tests a custom query template
+func TestDorisSubscriber_CustomTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	d := &DorisSubscriber{
+		Database:      "db",
+		Table:         "tbl",
+		QueryColumns:  []string{"event_time", "body"},
+		QueryTemplate: "select %[1]s from %[2]s.%[3]s where %[4]s >= %[5]v",
+		client:        db,
+		lastTimestamp: 5,
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("select event_time, body from db.tbl where event_time >= 5")).WillReturnRows(
+		sqlmock.NewRows([]string{"event_time", "body"}).AddRow(int64(6), "payload"))
+
+	logGroup, err := d.queryRecords()
+	require.NoError(t, err)
+	require.Len(t, logGroup.Logs, 1)
+	assert.Equal(t, "body", logGroup.Logs[0].Contents[0].Key)
+	assert.Equal(t, "payload", logGroup.Logs[0].Contents[0].Value)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestDorisSubscriber_TimeUnit tests second and millisecond timestamps, including ones past 2038
+func TestDorisSubscriber_TimeUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeUnit  string
+		since     string
+		timestamp int64
+		second    uint32
+		nano      uint32
+	}{
+		{name: "default unit", since: "1700000000", timestamp: 2200000000, second: 2200000000},
+		{name: "seconds", timeUnit: "s", since: "1700000000", timestamp: 2200000000, second: 2200000000},
+		{name: "millis", timeUnit: "ms", since: "1700000000000", timestamp: 2200000000123, second: 2200000000, nano: 123000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			d := &DorisSubscriber{Database: "db", Table: "tbl", TimeUnit: tt.timeUnit, client: db}
+			query := "select time, content, value from `db`.`tbl` where time > " + tt.since + " order by time limit 100"
+			mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(
+				sqlmock.NewRows([]string{"time", "content", "value"}).AddRow(tt.timestamp, "hello", "1"))
+
+			logGroups, err := d.GetData("", 1700000000)
+			require.NoError(t, err)
+			require.Len(t, logGroups[0].Logs, 1)
+			log := logGroups[0].Logs[0]
+			assert.Equal(t, tt.second, log.Time)
+			if tt.timeUnit == "ms" {
+				require.NotNil(t, log.TimeNs)
+				assert.Equal(t, tt.nano, *log.TimeNs)
+			}
+			assert.Equal(t, tt.timestamp, d.lastTimestamp)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+
+	_, err := factory[dorisName](map[string]interface{}{"address": "http://doris:8030", "database": "db", "table": "tbl", "time_unit": "us"})
+	assert.EqualError(t, err, "time unit must be s or ms")
+}
+
+// TestDorisSubscriber_TLS tests the tls parameter of the query connection DSN
+func TestDorisSubscriber_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	tests := []struct {
+		name     string
+		tls      string
+		caFile   string
+		expected string
+	}{
+		{name: "no tls", expected: "root:pwd@tcp(doris:9030)/db"},
+		{name: "verified", tls: "true", expected: "root:pwd@tcp(doris:9030)/db?tls=true"},
+		{name: "skip verify", tls: "skip-verify", expected: "root:pwd@tcp(doris:9030)/db?tls=skip-verify"},
+		{name: "custom", tls: "custom", caFile: caFile, expected: "root:pwd@tcp(doris:9030)/db?tls=doris-subscriber"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DorisSubscriber{Username: "root", Password: "pwd", Database: "db", TLS: tt.tls, TLSCAFile: tt.caFile}
+			dsn, err := d.buildDSN("doris", "9030")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, dsn)
+			_, err = mysql.ParseDSN(dsn)
+			assert.NoError(t, err)
+		})
+	}
+
+	d := &DorisSubscriber{Database: "db", TLS: "custom", TLSCAFile: filepath.Join(t.TempDir(), "missing.pem")}
+	_, err := d.buildDSN("doris", "9030")
+	assert.ErrorContains(t, err, "read tls ca file")
+
+	_, err = factory[dorisName](map[string]interface{}{"address": "http://doris:8030", "database": "db", "table": "tbl", "tls": "custom"})
+	assert.EqualError(t, err, "tls ca file must not be empty with custom tls")
+}
+
+// TestDorisSubscriber_Password tests that an empty password is kept unless a default password is configured
+func TestDorisSubscriber_Password(t *testing.T) {
+	tests := []struct {
+		name            string
+		password        string
+		defaultPassword string
+		expected        string
+	}{
+		{name: "empty password", expected: "root:@tcp(doris:9030)/db"},
+		{name: "default password", defaultPassword: "test", expected: "root:test@tcp(doris:9030)/db"},
+		{name: "configured password", password: "pwd", defaultPassword: "test", expected: "root:pwd@tcp(doris:9030)/db"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &DorisSubscriber{Username: "root", Password: tt.password, DefaultPassword: tt.defaultPassword, Database: "db"}
+			dsn, err := d.buildDSN("doris", "9030")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, dsn)
+		})
+	}
+}
+
+// TestDorisSubscriber_ConnectRetry tests that a failed ping is retried before querying
+func TestDorisSubscriber_ConnectRetry(t *testing.T) {
+	starting, startingMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	startingMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	startingMock.ExpectClose()
+	ready, readyMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	defer ready.Close()
+	readyMock.ExpectPing()
+	readyMock.ExpectQuery("select").WillReturnRows(sqlmock.NewRows([]string{"time", "content", "value"}))
+
+	dbs := []*sql.DB{starting, ready}
+	var dsns []string
+	d := &DorisSubscriber{
+		Address:                "http://doris:8030",
+		Username:               "root",
+		Database:               "db",
+		Table:                  "tbl",
+		ConnectAttempts:        3,
+		ConnectRetryIntervalMs: 1,
+		open: func(dsn string) (*sql.DB, error) {
+			dsns = append(dsns, dsn)
+			db := dbs[0]
+			dbs = dbs[1:]
+			return db, nil
+		},
+	}
+	_, err = d.GetData("", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root:@tcp(doris:9030)/db", "root:@tcp(doris:9030)/db"}, dsns)
+	assert.Same(t, ready, d.client)
+	assert.NoError(t, startingMock.ExpectationsWereMet())
+	assert.NoError(t, readyMock.ExpectationsWereMet())
+
+	// The last error is returned once the attempts are exhausted
+	failing, failingMock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	failingMock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	d = &DorisSubscriber{ConnectAttempts: 1, open: func(string) (*sql.DB, error) { return failing, nil }}
+	_, err = d.connect("doris:9030", "dsn")
+	assert.EqualError(t, err, "connection refused")
+}
+
+// TestDorisSubscriber_Pagination tests that more rows than the limit are all returned by a single call
+func TestDorisSubscriber_Pagination(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	// The rows of timestamp 100 go on beyond the first page
+	firstPage := sqlmock.NewRows([]string{"time", "content", "value"})
+	for i := 1; i <= 100; i++ {
+		firstPage.AddRow(int64(i), fmt.Sprintf("log-%d", i), nil)
+	}
+	secondPage := sqlmock.NewRows([]string{"time", "content", "value"}).AddRow(int64(100), "log-100", nil)
+	secondPage.AddRow(int64(100), "log-100-bis", nil)
+	for i := 101; i <= 150; i++ {
+		secondPage.AddRow(int64(i), fmt.Sprintf("log-%d", i), nil)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("where time > 0 order by time limit 100")).WillReturnRows(firstPage)
+	mock.ExpectQuery(regexp.QuoteMeta("where time > 99 order by time limit 100")).WillReturnRows(secondPage)
+
+	d := &DorisSubscriber{Database: "db", Table: "tbl", client: db}
+	logGroup, err := d.queryRecords()
+	require.NoError(t, err)
+	require.Len(t, logGroup.Logs, 151)
+	for i, log := range logGroup.Logs {
+		expected := fmt.Sprintf("log-%d", i+1)
+		if i == 100 {
+			expected = "log-100-bis"
+		} else if i > 100 {
+			expected = fmt.Sprintf("log-%d", i)
+		}
+		assert.Equal(t, expected, log.Contents[0].Value)
+	}
+	assert.Equal(t, int64(150), d.lastTimestamp)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// A full page sharing a single timestamp is kept as is
+	mock.ExpectQuery(regexp.QuoteMeta("where time > 150 order by time limit 2")).WillReturnRows(
+		sqlmock.NewRows([]string{"time", "content", "value"}).AddRow(int64(151), "a", nil).AddRow(int64(151), "b", nil))
+	mock.ExpectQuery(regexp.QuoteMeta("where time > 151 order by time limit 2")).WillReturnRows(
+		sqlmock.NewRows([]string{"time", "content", "value"}).AddRow(int64(152), "c", nil))
+	d.QueryLimit = 2
+	logGroup, err = d.queryRecords()
+	require.NoError(t, err)
+	assert.Len(t, logGroup.Logs, 3)
+	assert.Equal(t, int64(152), d.lastTimestamp)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ go 1.23.12
 
 require (
 	github.com/ClickHouse/clickhouse-go/v2 v2.6.0
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/IBM/sarama v1.42.2
 	github.com/alibaba/ilogtail/pkg v0.0.0
 	github.com/alibabacloud-go/darabonba-openapi/v2 v2.0.5
@@ -19,6 +20,7 @@ require (
 	github.com/influxdata/influxdb1-client v0.0.0-20220302092344-a9ab5670611c
 	github.com/melbahja/goph v1.4.0
 	github.com/mitchellh/mapstructure v1.5.0
+	github.com/stretchr/testify v1.11.1
 	github.com/testcontainers/testcontainers-go v0.37.0
 	github.com/testcontainers/testcontainers-go/modules/compose v0.37.0
 	golang.org/x/crypto v0.37.0
@@ -213,7 +215,6 @@ require (
 	github.com/skratchdot/open-golang v0.0.0-20200116055534-eef842397966 // indirect
 	github.com/spf13/cobra v1.9.1 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
-	github.com/stretchr/testify v1.11.1 // indirect
 	github.com/theupdateframework/notary v0.7.0 // indirect
 	github.com/tilt-dev/fsnotify v1.4.8-0.20220602155310-fff9c274a375 // indirect
 	github.com/tjfoc/gmsm v1.4.1 // indirect